@@ -0,0 +1,13 @@
+data "basistheory_application" "by_id" {
+  id = "45c124e7-6ab2-4899-b4d9-1388b0ba9d04"
+}
+
+data "basistheory_application" "by_name" {
+  name = "Shared Proxy Application"
+}
+
+resource "basistheory_proxy" "my_proxy" {
+  name            = "My Proxy"
+  destination_url = "https://httpbin.org/post"
+  application_id  = data.basistheory_application.by_name.id
+}
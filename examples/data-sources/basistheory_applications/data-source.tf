@@ -0,0 +1,15 @@
+data "basistheory_applications" "private_revealers" {
+  type       = "private"
+  permission = "token:reveal"
+}
+
+data "basistheory_applications" "proxies" {
+  name_regex = "^Proxy - "
+}
+
+output "proxy_application_ids" {
+  value = {
+    for application in data.basistheory_applications.proxies.applications :
+    application.name => application.id
+  }
+}
@@ -0,0 +1,19 @@
+data "basistheory_permissions" "private" {
+  application_type = "private"
+}
+
+resource "basistheory_application" "token_manager" {
+  name = "Token manager"
+  type = "private"
+  permissions = [
+    for permission in data.basistheory_permissions.private.types :
+    permission if startswith(permission, "token:")
+  ]
+
+  lifecycle {
+    precondition {
+      condition     = contains(data.basistheory_permissions.private.types, "token:reveal")
+      error_message = "The Tenant can't grant token:reveal to private Applications."
+    }
+  }
+}
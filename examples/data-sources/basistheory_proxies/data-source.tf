@@ -0,0 +1,10 @@
+data "basistheory_proxies" "shared" {
+  name_regex = "^Shared "
+}
+
+output "shared_proxy_ids" {
+  value = {
+    for proxy in data.basistheory_proxies.shared.proxies :
+    proxy.name => proxy.id
+  }
+}
@@ -0,0 +1,8 @@
+data "basistheory_proxy" "shared" {
+  name = "Shared Outbound Proxy"
+}
+
+output "shared_proxy_key" {
+  value     = data.basistheory_proxy.shared.key
+  sensitive = true
+}
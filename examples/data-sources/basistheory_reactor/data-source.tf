@@ -0,0 +1,7 @@
+data "basistheory_reactor" "shared" {
+  id = "5d4e8f5b-4b5f-4f0a-9c84-1b1a6c0f1d42"
+}
+
+output "shared_reactor_state" {
+  value = data.basistheory_reactor.shared.state
+}
@@ -0,0 +1,7 @@
+data "basistheory_reactors" "shared" {
+  name_regex = "^Shared "
+}
+
+output "shared_reactor_ids" {
+  value = data.basistheory_reactors.shared.ids
+}
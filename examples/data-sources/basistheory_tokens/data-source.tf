@@ -0,0 +1,25 @@
+data "basistheory_tokens" "upstream_api_key" {
+  query = "metadata.purpose:upstream-api-key metadata.env:prod"
+  size  = 1
+}
+
+resource "basistheory_proxy" "upstream" {
+  name            = "Upstream API"
+  destination_url = "https://api.example.com"
+  require_auth    = true
+
+  request_transforms {
+    type = "append_header"
+    options {
+      value    = "Bearer {{ token: ${data.basistheory_tokens.upstream_api_key.ids[0]} }}"
+      location = "Authorization"
+    }
+  }
+
+  lifecycle {
+    precondition {
+      condition     = length(data.basistheory_tokens.upstream_api_key.ids) == 1
+      error_message = "No upstream API key token was found for prod."
+    }
+  }
+}
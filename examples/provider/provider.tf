@@ -2,4 +2,7 @@ provider "basistheory" {
   api_url        = "https://api.basistheory.com"
   api_key        = "<YOUR_API_KEY_HERE>"
   client_timeout = 10
+  max_retries    = 3
+  retry_min_wait = 1
+  retry_max_wait = 30
 }
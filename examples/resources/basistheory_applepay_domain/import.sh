@@ -0,0 +1,3 @@
+# A Tenant has a single set of Apple Pay domain registrations, which is
+# imported using the fixed id `applepayDomains`.
+terraform import basistheory_applepay_domain.my_domains applepayDomains
@@ -0,0 +1,2 @@
+# Apple Pay domain registrations are imported using the domain.
+terraform import basistheory_applepay_domain_registration.checkout checkout.example.com
@@ -0,0 +1,3 @@
+resource "basistheory_applepay_domain_registration" "checkout" {
+  domain = "checkout.example.com"
+}
@@ -0,0 +1,4 @@
+# Application Keys are imported using the Application id and the key id,
+# separated by a slash. The key itself is only returned on creation, so it
+# is not available in state after import.
+terraform import basistheory_application_key.my_key 45c124e7-6ab2-4899-b4d9-1388b0ba9d04/b2f0c1d4-8d3e-4e0f-9a61-4f3e2b1c0d9a
@@ -0,0 +1,6 @@
+# Google Pay Merchant Certificates are imported using the Merchant Registration
+# id and the certificate id, separated by a slash. The certificate data and
+# password cannot be read back, so they are ignored after import instead of
+# forcing a replacement. Use `terraform apply -replace` to upload a new
+# certificate for an imported resource.
+terraform import basistheory_google_pay_merchant_certificates.example 7f3a2b1c-4d5e-4f60-8a9b-0c1d2e3f4a5b/2e4c6a8b-1d3f-4a5b-9c7d-8e0f1a2b3c4d
@@ -0,0 +1,2 @@
+# Google Pay Merchant Registrations are imported using their id.
+terraform import basistheory_google_pay_merchant_registration.example 7f3a2b1c-4d5e-4f60-8a9b-0c1d2e3f4a5b
@@ -26,6 +26,28 @@ resource "basistheory_proxy" "request_transform_proxy" {
   }
 }
 
+# Proxy using a structured token_template instead of a JSON encoded token
+resource "basistheory_proxy" "token_template_proxy" {
+  name            = "Token Template Proxy"
+  destination_url = "https://api.example.com/payments"
+  require_auth    = true
+
+  request_transforms {
+    type = "tokenize"
+    options {
+      identifier = "requestCardToken"
+      token_template {
+        type       = "card"
+        data       = "{{ encrypted | json: '$.data' }}"
+        containers = ["/pci/high/"]
+        metadata = {
+          source = "proxy-request"
+        }
+      }
+    }
+  }
+}
+
 # Proxy with Response Transforms - processes outgoing responses
 resource "basistheory_proxy" "response_transform_proxy" {
   name            = "Response Transform Proxy"
@@ -89,3 +111,11 @@ output "response_proxy_key" {
   description = "Response transform proxy key"
   sensitive   = true
 }
+
+# Proxy that cannot be destroyed or replaced until deletion_protection is
+# set to false in a separate apply
+resource "basistheory_proxy" "protected_proxy" {
+  name                = "Protected Proxy"
+  destination_url     = "https://api.example.com/payments"
+  deletion_protection = true
+}
@@ -11,7 +11,12 @@ resource "basistheory_reactor" "my_reactor" {
     };
   };
   EOT
-  configuration = {
+  sensitive_configuration = {
     SERVICE_API_KEY = "key_abcd1234"
   }
+
+  timeouts {
+    create = "20m"
+    update = "20m"
+  }
 }
@@ -0,0 +1,4 @@
+# Tokens are imported using their id. The token's data is never read back, as
+# the API masks it for keys without token:reveal, so it is ignored after import
+# instead of forcing a replacement. Use `terraform apply -replace` to change it.
+terraform import basistheory_token.upstream_api_key 8f4b0b1e-2f5c-4a7e-9d3b-6c1a2e4f5b7d
@@ -0,0 +1,28 @@
+variable "upstream_api_key" {
+  type      = string
+  sensitive = true
+}
+
+resource "basistheory_token" "upstream_api_key" {
+  type = "token"
+  data = var.upstream_api_key
+  metadata = {
+    purpose = "upstream-api-key"
+    env     = "prod"
+  }
+  containers = ["/general/high/"]
+}
+
+resource "basistheory_proxy" "upstream" {
+  name            = "Upstream API"
+  destination_url = "https://api.example.com"
+  require_auth    = true
+
+  request_transforms {
+    type = "append_header"
+    options {
+      value    = "Bearer {{ token: ${basistheory_token.upstream_api_key.id} }}"
+      location = "Authorization"
+    }
+  }
+}
@@ -0,0 +1,2 @@
+# Webhooks are imported using their id.
+terraform import basistheory_webhook.my_webhook 5c8e7a9f-3b1d-4f6e-a2c4-9d0b1e2f3a4b
@@ -0,0 +1,126 @@
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// codeDirEntrypoint is the module of a code_dir whose exports become the
+// exports of the bundle.
+const codeDirEntrypoint = "index.js"
+
+func codeSha256(code string) string {
+	sum := sha256.Sum256([]byte(code))
+	return hex.EncodeToString(sum[:])
+}
+
+func readCodeFile(path string) (string, error) {
+	code, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("reading code file: %w", err)
+	}
+
+	return string(code), nil
+}
+
+// bundleCodeDir bundles the .js files of dir into a single CommonJS module
+// exporting whatever index.js exports. Relative requires between bundled files
+// are resolved inside the bundle; everything else, such as runtime
+// dependencies, falls through to the platform's require. node_modules and
+// hidden directories are skipped.
+func bundleCodeDir(dir string) (string, error) {
+	modules := map[string]string{}
+
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if entry.IsDir() {
+			if path != dir && (entry.Name() == "node_modules" || strings.HasPrefix(entry.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if filepath.Ext(path) != ".js" {
+			return nil
+		}
+
+		relativePath, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+
+		code, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+
+		modules[filepath.ToSlash(relativePath)] = string(code)
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("reading code directory: %w", err)
+	}
+
+	if _, ok := modules[codeDirEntrypoint]; !ok {
+		return "", fmt.Errorf("code directory %s has no %s entrypoint", dir, codeDirEntrypoint)
+	}
+
+	names := make([]string, 0, len(modules))
+	for name := range modules {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var bundle strings.Builder
+	bundle.WriteString("module.exports = (function (nativeRequire) {\n")
+	bundle.WriteString("  const modules = {\n")
+	for _, name := range names {
+		quotedName, _ := json.Marshal(name)
+		fmt.Fprintf(&bundle, "    %s: function (module, exports, require) {\n%s\n    },\n", quotedName, modules[name])
+	}
+	bundle.WriteString(codeDirBundleLoader)
+	fmt.Fprintf(&bundle, "  return load(%q);\n", codeDirEntrypoint)
+	bundle.WriteString("})(require);\n")
+
+	return bundle.String(), nil
+}
+
+const codeDirBundleLoader = `  };
+  const cache = {};
+  function resolve(from, request) {
+    if (!request.startsWith("./") && !request.startsWith("../")) {
+      return undefined;
+    }
+    const segments = from.split("/").slice(0, -1);
+    for (const segment of request.split("/")) {
+      if (segment === "..") {
+        segments.pop();
+      } else if (segment !== "." && segment !== "") {
+        segments.push(segment);
+      }
+    }
+    const path = segments.join("/");
+    return [path, path + ".js", path + "/index.js"].find((candidate) => candidate in modules);
+  }
+  function load(name) {
+    if (!cache[name]) {
+      const module = { exports: {} };
+      cache[name] = module;
+      modules[name](module, module.exports, function (request) {
+        const resolved = resolve(name, request);
+        return resolved === undefined ? nativeRequire(request) : load(resolved);
+      });
+    }
+    return cache[name].exports;
+  }
+`
@@ -0,0 +1,76 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCodeSha256(t *testing.T) {
+	if actual := codeSha256(""); actual != "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" {
+		t.Fatalf("expected a hex encoded SHA-256, got %q", actual)
+	}
+}
+
+func TestReadCodeFile_reportsMissingFile(t *testing.T) {
+	if _, err := readCodeFile(filepath.Join(t.TempDir(), "missing.js")); err == nil || !strings.Contains(err.Error(), "reading code file") {
+		t.Fatalf("expected missing file to be reported, got %v", err)
+	}
+}
+
+func TestBundleCodeDir(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCodeFile(t, dir, "index.js", `module.exports = require("./lib/greet");`)
+	writeTestCodeFile(t, dir, "lib/greet.js", `module.exports = async function () { return "hello"; };`)
+	writeTestCodeFile(t, dir, "README.md", "# not bundled")
+	writeTestCodeFile(t, dir, "node_modules/axios/index.js", "module.exports = {};")
+	writeTestCodeFile(t, dir, ".cache/stale.js", "module.exports = {};")
+
+	actual, err := bundleCodeDir(dir)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for _, expectedPart := range []string{
+		"module.exports = (function (nativeRequire) {",
+		`"index.js": function (module, exports, require) {`,
+		`"lib/greet.js": function (module, exports, require) {`,
+		`module.exports = async function () { return "hello"; };`,
+		`return load("index.js");`,
+	} {
+		if !strings.Contains(actual, expectedPart) {
+			t.Fatalf("expected bundle to contain %q, got:\n%s", expectedPart, actual)
+		}
+	}
+	for _, unexpectedPart := range []string{"README", "node_modules", "stale.js"} {
+		if strings.Contains(actual, unexpectedPart) {
+			t.Fatalf("expected bundle not to contain %q, got:\n%s", unexpectedPart, actual)
+		}
+	}
+
+	if again, _ := bundleCodeDir(dir); again != actual {
+		t.Fatalf("expected bundling to be deterministic")
+	}
+}
+
+func TestBundleCodeDir_requiresEntrypoint(t *testing.T) {
+	dir := t.TempDir()
+	writeTestCodeFile(t, dir, "main.js", "module.exports = {};")
+
+	if _, err := bundleCodeDir(dir); err == nil || !strings.Contains(err.Error(), "has no index.js entrypoint") {
+		t.Fatalf("expected missing entrypoint to be reported, got %v", err)
+	}
+}
+
+func writeTestCodeFile(t *testing.T, dir string, name string, code string) string {
+	path := filepath.Join(dir, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if err := os.WriteFile(path, []byte(code), 0o644); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	return path
+}
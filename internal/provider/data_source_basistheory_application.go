@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBasisTheoryApplication() *schema.Resource {
+	applicationSchema := dataSourceSchemaFromResourceSchema(resourceBasisTheoryApplication().Schema, "key", "create_key", deletionProtectionKey)
+
+	applicationSchema["id"] = &schema.Schema{
+		Description:  "Unique identifier of the Application to look up. Exactly one of `id` or `name` must be set",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	applicationSchema["name"] = &schema.Schema{
+		Description:  "Name of the Application to look up. The name must match exactly one Application in the Tenant",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return &schema.Resource{
+		Description: "Looks up an Application by id or name https://developers.basistheory.com/docs/api/applications",
+
+		ReadContext: dataSourceApplicationRead,
+
+		Schema: applicationSchema,
+	}
+}
+
+func dataSourceApplicationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	var application *basistheory.Application
+
+	if id, ok := data.GetOk("id"); ok {
+		var err error
+		application, err = basisTheoryClient.Applications.Get(ctx, id.(string))
+
+		if err != nil {
+			var notFoundError *basistheory.NotFoundError
+			if errors.As(err, &notFoundError) {
+				return diag.Errorf("Application %s not found", id)
+			}
+			return apiErrorDiagnostics("Error reading Application:", err)
+		}
+	} else {
+		name := data.Get("name").(string)
+
+		applications, err := listApplications(ctx, basisTheoryClient, &basistheory.ApplicationsListRequest{})
+		if err != nil {
+			return apiErrorDiagnostics("Error listing Applications:", err)
+		}
+
+		var matches []*basistheory.Application
+		for _, candidate := range applications {
+			if getStringValue(candidate.Name) == name {
+				matches = append(matches, candidate)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("No Application found with name %q", name)
+		case 1:
+			application = matches[0]
+		default:
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, getStringValue(match.ID))
+			}
+			return multipleMatchesDiagnostics("Application", "Applications", name, ids)
+		}
+	}
+
+	data.SetId(getStringValue(application.ID))
+
+	for applicationDatumName, applicationDatum := range flattenApplication(application) {
+		if err := data.Set(applicationDatumName, applicationDatum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// listApplications pages through the Applications list API and returns every
+// Application matching the request.
+func listApplications(ctx context.Context, client *basistheoryClient.Client, request *basistheory.ApplicationsListRequest) ([]*basistheory.Application, error) {
+	page, err := client.Applications.List(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var applications []*basistheory.Application
+	iterator := page.Iterator()
+	for iterator.Next(ctx) {
+		applications = append(applications, iterator.Current())
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return applications, nil
+}
+
+func flattenApplication(application *basistheory.Application) map[string]interface{} {
+	modifiedAt := ""
+	if application.ModifiedAt != nil {
+		modifiedAt = application.ModifiedAt.String()
+	}
+
+	createdAt := ""
+	if application.CreatedAt != nil {
+		createdAt = application.CreatedAt.String()
+	}
+
+	return map[string]interface{}{
+		"id":          getStringValue(application.ID),
+		"tenant_id":   getStringValue(application.TenantID),
+		"name":        getStringValue(application.Name),
+		"type":        getStringValue(application.Type),
+		"permissions": application.Permissions,
+		"rule":        flattenAccessRuleData(application.Rules),
+		"created_at":  createdAt,
+		"created_by":  getStringValue(application.CreatedBy),
+		"modified_at": modifiedAt,
+		"modified_by": getStringValue(application.ModifiedBy),
+	}
+}
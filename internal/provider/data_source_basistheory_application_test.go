@@ -0,0 +1,89 @@
+package provider
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceApplication(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceApplication, "terraform_test_application_data_source", "(Deletable) Terraform data source application"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_application.by_id", "id",
+						"basistheory_application.terraform_test_application_data_source", "id"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_application.by_id", "name", "(Deletable) Terraform data source application"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_application.by_id", "type", "private"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_application.by_id", "permissions.0", "token:read"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_application.by_id", "rule.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_application.by_name", "id",
+						"basistheory_application.terraform_test_application_data_source", "id"),
+					resource.TestCheckNoResourceAttr(
+						"data.basistheory_application.by_name", "key"),
+				),
+			},
+		},
+	})
+}
+
+func TestDataSourceApplication_RequiresIdOrName(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "basistheory_application" "missing" {}`,
+				ExpectError: regexp.MustCompile(`one of .id,name. must be specified`),
+			},
+		},
+	})
+}
+
+func TestDataSourceApplication_NameNotFound(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      `data "basistheory_application" "missing" { name = "(Deletable) Terraform application that does not exist" }`,
+				ExpectError: regexp.MustCompile(`No Application found with name`),
+			},
+		},
+	})
+}
+
+const testAccDataSourceApplication = `
+resource "basistheory_application" "%[1]s" {
+  name = "%[2]s"
+  type = "private"
+  permissions = ["token:read"]
+  rule {
+	description = "Test rule"
+	priority = 1
+	container = "/"
+	transform = "mask"
+	permissions = ["token:read"]
+  }
+}
+
+data "basistheory_application" "by_id" {
+  id = basistheory_application.%[1]s.id
+}
+
+data "basistheory_application" "by_name" {
+  name = basistheory_application.%[1]s.name
+}
+`
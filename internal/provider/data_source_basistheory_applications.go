@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBasisTheoryApplications() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the Applications in the Tenant, optionally filtered by type, name or permission https://developers.basistheory.com/docs/api/applications",
+
+		ReadContext: dataSourceApplicationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"type": {
+				Description:  "Only include Applications of this type",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private", "management"}, false),
+			},
+			"name_regex": {
+				Description:  "Only include Applications whose name matches this regular expression",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"permission": {
+				Description: "Only include Applications granted this permission",
+				Type:        schema.TypeString,
+				Optional:    true,
+			},
+			"ids": {
+				Description: "Identifiers of the matching Applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"applications": {
+				Description: "The matching Applications",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: dataSourceSchemaFromResourceSchema(resourceBasisTheoryApplication().Schema, "key", "create_key", deletionProtectionKey),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceApplicationsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	request := &basistheory.ApplicationsListRequest{}
+	applicationType := data.Get("type").(string)
+	if applicationType != "" {
+		request.Type = []*string{&applicationType}
+	}
+
+	var nameRegex *regexp.Regexp
+	if value := data.Get("name_regex").(string); value != "" {
+		nameRegex = regexp.MustCompile(value)
+	}
+
+	permission := data.Get("permission").(string)
+
+	applications, err := listApplications(ctx, basisTheoryClient, request)
+	if err != nil {
+		return apiErrorDiagnostics("Error listing Applications:", err)
+	}
+
+	ids := make([]string, 0)
+	flattenedApplications := make([]interface{}, 0)
+	for _, application := range applications {
+		if applicationType != "" && getStringValue(application.Type) != applicationType {
+			continue
+		}
+		if nameRegex != nil && !nameRegex.MatchString(getStringValue(application.Name)) {
+			continue
+		}
+		if permission != "" && !containsString(application.Permissions, permission) {
+			continue
+		}
+
+		ids = append(ids, getStringValue(application.ID))
+		flattenedApplications = append(flattenedApplications, flattenApplication(application))
+	}
+
+	data.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s|%s|%s", applicationType, data.Get("name_regex"), permission))))
+
+	if err := data.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := data.Set("applications", flattenedApplications); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
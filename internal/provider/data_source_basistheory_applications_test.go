@@ -0,0 +1,81 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceApplications(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceApplications, "terraform_test_applications_data_source"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.basistheory_applications.by_name", "applications.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_applications.by_name", "ids.0",
+						"basistheory_application.terraform_test_applications_data_source", "id"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_applications.by_name", "applications.0.type", "private"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_applications.by_name", "applications.0.rule.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_applications.by_permission", "applications.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_applications.wrong_type", "applications.#", "0"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_applications.wrong_permission", "applications.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceApplications = `
+resource "basistheory_application" "%[1]s" {
+  name = "(Deletable) Terraform applications data source %[1]s"
+  type = "private"
+  permissions = ["token:read"]
+  rule {
+	description = "Test rule"
+	priority = 1
+	container = "/"
+	transform = "mask"
+	permissions = ["token:read"]
+  }
+}
+
+data "basistheory_applications" "by_name" {
+  name_regex = "^\\(Deletable\\) Terraform applications data source %[1]s$"
+
+  depends_on = [basistheory_application.%[1]s]
+}
+
+data "basistheory_applications" "by_permission" {
+  type       = "private"
+  name_regex = "%[1]s$"
+  permission = "token:read"
+
+  depends_on = [basistheory_application.%[1]s]
+}
+
+data "basistheory_applications" "wrong_type" {
+  type       = "management"
+  name_regex = "%[1]s$"
+
+  depends_on = [basistheory_application.%[1]s]
+}
+
+data "basistheory_applications" "wrong_permission" {
+  name_regex = "%[1]s$"
+  permission = "token:reveal"
+
+  depends_on = [basistheory_application.%[1]s]
+}
+`
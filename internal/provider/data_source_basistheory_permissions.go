@@ -0,0 +1,118 @@
+package provider
+
+import (
+	"context"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBasisTheoryPermissions() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the permissions Applications can be granted, optionally filtered by Application type https://developers.basistheory.com/docs/api/permissions",
+
+		ReadContext: dataSourcePermissionsRead,
+
+		Schema: map[string]*schema.Schema{
+			"application_type": {
+				Description:  "Only include permissions that Applications of this type can be granted",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringInSlice([]string{"public", "private", "management"}, false),
+			},
+			"types": {
+				Description: "Types of the matching permissions, such as `token:read`",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"permissions": {
+				Description: "The matching permissions",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"type": {
+							Description: "The permission, such as `token:read`",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"description": {
+							Description: "What the permission grants",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"application_types": {
+							Description: "Application types that can be granted the permission",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourcePermissionsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	request := &basistheory.PermissionsListRequest{}
+	applicationType := data.Get("application_type").(string)
+	if applicationType != "" {
+		request.ApplicationType = &applicationType
+	}
+
+	permissions, err := basisTheoryClient.Permissions.List(ctx, request)
+	if err != nil {
+		return apiErrorDiagnostics("Error listing Permissions:", err)
+	}
+
+	types := make([]string, 0)
+	flattenedPermissions := make([]interface{}, 0)
+	for _, permission := range permissions {
+		if applicationType != "" && !containsString(permission.ApplicationTypes, applicationType) {
+			continue
+		}
+
+		types = append(types, getStringValue(permission.Type))
+		flattenedPermissions = append(flattenedPermissions, flattenPermission(permission))
+	}
+
+	if applicationType == "" {
+		data.SetId("all")
+	} else {
+		data.SetId(applicationType)
+	}
+
+	if err := data.Set("types", types); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := data.Set("permissions", flattenedPermissions); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func flattenPermission(permission *basistheory.Permission) map[string]interface{} {
+	applicationTypes := make([]interface{}, 0, len(permission.ApplicationTypes))
+	for _, applicationType := range permission.ApplicationTypes {
+		applicationTypes = append(applicationTypes, applicationType)
+	}
+
+	return map[string]interface{}{
+		"type":              getStringValue(permission.Type),
+		"description":       getStringValue(permission.Description),
+		"application_types": applicationTypes,
+	}
+}
@@ -0,0 +1,45 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourcePermissions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourcePermissions,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(
+						"data.basistheory_permissions.private", "types.*", "token:read"),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.basistheory_permissions.private", "permissions.*", map[string]string{
+							"type": "token:reveal",
+						}),
+					resource.TestCheckTypeSetElemAttr(
+						"data.basistheory_permissions.management", "types.*", "application:create"),
+					resource.TestCheckTypeSetElemAttr(
+						"data.basistheory_permissions.all", "types.*", "application:create"),
+					resource.TestCheckTypeSetElemAttr(
+						"data.basistheory_permissions.all", "types.*", "token:read"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourcePermissions = `
+data "basistheory_permissions" "private" {
+  application_type = "private"
+}
+
+data "basistheory_permissions" "management" {
+  application_type = "management"
+}
+
+data "basistheory_permissions" "all" {}
+`
@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBasisTheoryProxies() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the Proxies in the Tenant, optionally filtered by name https://docs.basistheory.com/docs/api/proxies/pre-configured-proxies",
+
+		ReadContext: dataSourceProxiesRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Description:  "Only include Proxies whose name matches this regular expression",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": {
+				Description: "Identifiers of the matching Proxies",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"proxies": {
+				Description: "The matching Proxies",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: withSensitiveDataSourceConfiguration(dataSourceSchemaFromResourceSchema(resourceBasisTheoryProxy().Schema, "encrypted", "code_file", "code_sha256", sensitiveConfigurationKey, deletionProtectionKey, waitForActiveKey, acceptStatesKey)),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceProxiesRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	var nameRegex *regexp.Regexp
+	if value := data.Get("name_regex").(string); value != "" {
+		nameRegex = regexp.MustCompile(value)
+	}
+
+	proxies, err := listProxies(ctx, basisTheoryClient, &basistheory.ProxiesListRequest{})
+	if err != nil {
+		return apiErrorDiagnostics("Error listing Proxies:", err)
+	}
+
+	ids := make([]string, 0)
+	flattenedProxies := make([]interface{}, 0)
+	for _, proxy := range proxies {
+		if nameRegex != nil && !nameRegex.MatchString(getStringValue(proxy.Name)) {
+			continue
+		}
+
+		ids = append(ids, getStringValue(proxy.ID))
+		flattenedProxies = append(flattenedProxies, flattenProxy(proxy))
+	}
+
+	data.SetId(strconv.Itoa(schema.HashString(data.Get("name_regex").(string))))
+
+	if err := data.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := data.Set("proxies", flattenedProxies); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,46 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceProxies(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckProxyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceProxies, "terraform_test_proxies_data_source"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.basistheory_proxies.by_name", "proxies.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_proxies.by_name", "ids.0",
+						"basistheory_proxy.terraform_test_proxies_data_source", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_proxies.by_name", "proxies.0.key",
+						"basistheory_proxy.terraform_test_proxies_data_source", "key"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_proxies.by_name", "proxies.0.destination_url", "https://httpbin.org/post"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProxies = `
+resource "basistheory_proxy" "%[1]s" {
+  name            = "(Deletable) Terraform proxies data source %[1]s"
+  destination_url = "https://httpbin.org/post"
+}
+
+data "basistheory_proxies" "by_name" {
+  name_regex = "proxies data source %[1]s$"
+
+  depends_on = [basistheory_proxy.%[1]s]
+}
+`
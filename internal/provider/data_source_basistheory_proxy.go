@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBasisTheoryProxy() *schema.Resource {
+	proxySchema := withSensitiveDataSourceConfiguration(dataSourceSchemaFromResourceSchema(resourceBasisTheoryProxy().Schema, "encrypted", "code_file", "code_sha256", sensitiveConfigurationKey, deletionProtectionKey, waitForActiveKey, acceptStatesKey))
+
+	proxySchema["id"] = &schema.Schema{
+		Description:  "Unique identifier of the Proxy to look up. Exactly one of `id` or `name` must be set",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	proxySchema["name"] = &schema.Schema{
+		Description:  "Name of the Proxy to look up. The name must match exactly one Proxy in the Tenant",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return &schema.Resource{
+		Description: "Looks up a Proxy by id or name https://docs.basistheory.com/docs/api/proxies/pre-configured-proxies",
+
+		ReadContext: dataSourceProxyRead,
+
+		Schema: proxySchema,
+	}
+}
+
+func dataSourceProxyRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	var proxy *basistheory.Proxy
+
+	if id, ok := data.GetOk("id"); ok {
+		var err error
+		proxy, err = basisTheoryClient.Proxies.Get(ctx, id.(string))
+
+		if err != nil {
+			var notFoundError *basistheory.NotFoundError
+			if errors.As(err, &notFoundError) {
+				return diag.Errorf("Proxy %s not found", id)
+			}
+			return apiErrorDiagnostics("Error reading Proxy:", err)
+		}
+	} else {
+		name := data.Get("name").(string)
+
+		proxies, err := listProxies(ctx, basisTheoryClient, &basistheory.ProxiesListRequest{Name: &name})
+		if err != nil {
+			return apiErrorDiagnostics("Error listing Proxies:", err)
+		}
+
+		var matches []*basistheory.Proxy
+		for _, candidate := range proxies {
+			if getStringValue(candidate.Name) == name {
+				matches = append(matches, candidate)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("No Proxy found with name %q", name)
+		case 1:
+			proxy = matches[0]
+		default:
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, getStringValue(match.ID))
+			}
+			return multipleMatchesDiagnostics("Proxy", "Proxies", name, ids)
+		}
+	}
+
+	return setProxyDataSourceState(data, proxy)
+}
+
+// setProxyDataSourceState stores the Proxy as returned by the API. Unlike
+// setProxyState it leaves out the post-processing that depends on resource
+// configuration, which the data source schema does not have.
+func setProxyDataSourceState(data *schema.ResourceData, proxy *basistheory.Proxy) diag.Diagnostics {
+	data.SetId(*proxy.ID)
+
+	for proxyDatumName, proxyDatum := range flattenProxy(proxy) {
+		if err := data.Set(proxyDatumName, proxyDatum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// listProxies pages through the Proxies list API and returns every Proxy
+// matching the request.
+func listProxies(ctx context.Context, client *basistheoryClient.Client, request *basistheory.ProxiesListRequest) ([]*basistheory.Proxy, error) {
+	page, err := client.Proxies.List(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var proxies []*basistheory.Proxy
+	iterator := page.Iterator()
+	for iterator.Next(ctx) {
+		proxies = append(proxies, iterator.Current())
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return proxies, nil
+}
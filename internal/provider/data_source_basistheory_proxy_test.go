@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceProxy(t *testing.T) {
+	const resourceName = "terraform_test_proxy_data_source"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckProxyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLookupsConfig("proxy", resourceName, testAccDataSourceProxyAttributes),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDataSourceLookups("proxy", resourceName),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_proxy.by_id", "key",
+						"basistheory_proxy."+resourceName, "key"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_proxy.by_id", "destination_url", "https://httpbin.org/post"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_proxy.by_id", "state", "active"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_proxy.by_id", "request_transforms.0.type", "code"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_proxy.by_name", "request_transforms.0.code",
+						"basistheory_proxy."+resourceName, "request_transforms.0.code"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProxyAttributes = `
+  destination_url = "https://httpbin.org/post"
+  request_transforms {
+    type = "code"
+    code = <<-EOT
+              module.exports = async function (context) {
+                return context;
+              };
+          EOT
+  }`
+
+func TestProxyDataSourceState_setsOnlyDataSourceAttributes(t *testing.T) {
+	id, name, testFoo := "proxy-id", "Terraform proxy", "TEST_FOO"
+	destinationURL := "https://httpbin.org/post"
+	transformType, code := "code", "module.exports = async function (req) { return req; };"
+	data := schema.TestResourceDataRaw(t, dataSourceBasisTheoryProxy().Schema, map[string]interface{}{"id": id})
+
+	diags := setProxyDataSourceState(data, &basistheory.Proxy{
+		ID:                &id,
+		Name:              &name,
+		DestinationURL:    &destinationURL,
+		Configuration:     map[string]*string{"TEST_FOO": &testFoo},
+		RequestTransforms: []*basistheory.ProxyTransform{{Type: &transformType, Code: &code}},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if actual := data.Get("name").(string); actual != name {
+		t.Fatalf("expected name %q, got %q", name, actual)
+	}
+	if actual := data.Get("configuration.TEST_FOO").(string); actual != "TEST_FOO" {
+		t.Fatalf("expected configuration to be set, got %q", actual)
+	}
+	if actual := data.Get("request_transforms.0.code").(string); actual != code {
+		t.Fatalf("expected transform code to be kept, got %q", actual)
+	}
+}
+
+func TestDataSourceProxyRead_againstFakeAPI(t *testing.T) {
+	testDataSourceReadAgainstFakeAPI(t, dataSourceBasisTheoryProxy(), "Proxy", "Proxies", "proxies",
+		`{"name":%q,"destination_url":"https://httpbin.org/post","configuration":{"API_KEY":"key_abcd1234"}}`)
+}
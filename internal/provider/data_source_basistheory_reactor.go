@@ -0,0 +1,122 @@
+package provider
+
+import (
+	"context"
+	"errors"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceBasisTheoryReactor() *schema.Resource {
+	reactorSchema := withSensitiveDataSourceConfiguration(dataSourceSchemaFromResourceSchema(resourceBasisTheoryReactor().Schema, "code_file", "code_dir", sensitiveConfigurationKey, deletionProtectionKey, waitForActiveKey, acceptStatesKey))
+
+	reactorSchema["id"] = &schema.Schema{
+		Description:  "Unique identifier of the Reactor to look up. Exactly one of `id` or `name` must be set",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+	reactorSchema["name"] = &schema.Schema{
+		Description:  "Name of the Reactor to look up. The name must match exactly one Reactor in the Tenant",
+		Type:         schema.TypeString,
+		Optional:     true,
+		Computed:     true,
+		ExactlyOneOf: []string{"id", "name"},
+	}
+
+	return &schema.Resource{
+		Description: "Looks up a Reactor by id or name https://docs.basistheory.com/docs/api/reactors",
+
+		ReadContext: dataSourceReactorRead,
+
+		Schema: reactorSchema,
+	}
+}
+
+func dataSourceReactorRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	var reactor *basistheory.Reactor
+
+	if id, ok := data.GetOk("id"); ok {
+		var err error
+		reactor, err = basisTheoryClient.Reactors.Get(ctx, id.(string))
+
+		if err != nil {
+			var notFoundError *basistheory.NotFoundError
+			if errors.As(err, &notFoundError) {
+				return diag.Errorf("Reactor %s not found", id)
+			}
+			return apiErrorDiagnostics("Error reading Reactor:", err)
+		}
+	} else {
+		name := data.Get("name").(string)
+
+		reactors, err := listReactors(ctx, basisTheoryClient, &basistheory.ReactorsListRequest{Name: &name})
+		if err != nil {
+			return apiErrorDiagnostics("Error listing Reactors:", err)
+		}
+
+		var matches []*basistheory.Reactor
+		for _, candidate := range reactors {
+			if getStringValue(candidate.Name) == name {
+				matches = append(matches, candidate)
+			}
+		}
+
+		switch len(matches) {
+		case 0:
+			return diag.Errorf("No Reactor found with name %q", name)
+		case 1:
+			reactor = matches[0]
+		default:
+			var ids []string
+			for _, match := range matches {
+				ids = append(ids, getStringValue(match.ID))
+			}
+			return multipleMatchesDiagnostics("Reactor", "Reactors", name, ids)
+		}
+	}
+
+	return setReactorDataSourceState(data, reactor)
+}
+
+// setReactorDataSourceState stores the Reactor as returned by the API. Unlike
+// setReactorState it leaves out the post-processing that depends on resource
+// configuration, which the data source schema does not have.
+func setReactorDataSourceState(data *schema.ResourceData, reactor *basistheory.Reactor) diag.Diagnostics {
+	data.SetId(*reactor.ID)
+
+	for reactorDatumName, reactorDatum := range flattenReactor(reactor) {
+		if err := data.Set(reactorDatumName, reactorDatum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// listReactors pages through the Reactors list API and returns every Reactor
+// matching the request.
+func listReactors(ctx context.Context, client *basistheoryClient.Client, request *basistheory.ReactorsListRequest) ([]*basistheory.Reactor, error) {
+	page, err := client.Reactors.List(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	var reactors []*basistheory.Reactor
+	iterator := page.Iterator()
+	for iterator.Next(ctx) {
+		reactors = append(reactors, iterator.Current())
+	}
+
+	if err := iterator.Err(); err != nil {
+		return nil, err
+	}
+
+	return reactors, nil
+}
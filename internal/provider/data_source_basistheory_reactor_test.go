@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"testing"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceReactor(t *testing.T) {
+	const resourceName = "terraform_test_reactor_data_source"
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckReactorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceLookupsConfig("reactor", resourceName, testAccDataSourceReactorAttributes),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckDataSourceLookups("reactor", resourceName),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_reactor.by_id", "code",
+						"basistheory_reactor."+resourceName, "code"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_reactor.by_id", "state", "active"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_reactor.by_id", "configuration.TEST_FOO", "TEST_FOO"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_reactor.by_id", "runtime.0.image", "node22"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceReactorAttributes = `
+  code = <<-EOT
+            module.exports = async function (context) {
+              return context;
+            };
+        EOT
+  configuration = {
+    TEST_FOO = "TEST_FOO"
+  }
+  runtime {
+    image = "node22"
+  }`
+
+func TestReactorDataSourceState_setsOnlyDataSourceAttributes(t *testing.T) {
+	id, name, testFoo := "reactor-id", "Terraform reactor", "TEST_FOO"
+	code := "module.exports = async function (context) { return context; };"
+	data := schema.TestResourceDataRaw(t, dataSourceBasisTheoryReactor().Schema, map[string]interface{}{"id": id})
+
+	diags := setReactorDataSourceState(data, &basistheory.Reactor{
+		ID:            &id,
+		Name:          &name,
+		Code:          &code,
+		Configuration: map[string]*string{"TEST_FOO": &testFoo},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if actual := data.Get("name").(string); actual != name {
+		t.Fatalf("expected name %q, got %q", name, actual)
+	}
+	if actual := data.Get("configuration.TEST_FOO").(string); actual != "TEST_FOO" {
+		t.Fatalf("expected configuration to be set, got %q", actual)
+	}
+	if actual := data.Get("code").(string); actual != code {
+		t.Fatalf("expected code to be kept, got %q", actual)
+	}
+	if actual := data.Get("code_sha256").(string); actual != codeSha256(code) {
+		t.Fatalf("expected code_sha256 to hash the code, got %q", actual)
+	}
+}
+
+func TestDataSourceReactorRead_againstFakeAPI(t *testing.T) {
+	testDataSourceReadAgainstFakeAPI(t, dataSourceBasisTheoryReactor(), "Reactor", "Reactors", "reactors",
+		`{"name":%q,"code":"module.exports = async function (context) { return context; };","configuration":{"API_KEY":"key_abcd1234"}}`)
+}
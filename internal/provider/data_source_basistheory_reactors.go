@@ -0,0 +1,83 @@
+package provider
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBasisTheoryReactors() *schema.Resource {
+	return &schema.Resource{
+		Description: "Lists the Reactors in the Tenant, optionally filtered by name https://docs.basistheory.com/docs/api/reactors",
+
+		ReadContext: dataSourceReactorsRead,
+
+		Schema: map[string]*schema.Schema{
+			"name_regex": {
+				Description:  "Only include Reactors whose name matches this regular expression",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ValidateFunc: validation.StringIsValidRegExp,
+			},
+			"ids": {
+				Description: "Identifiers of the matching Reactors",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"reactors": {
+				Description: "The matching Reactors",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: withSensitiveDataSourceConfiguration(dataSourceSchemaFromResourceSchema(resourceBasisTheoryReactor().Schema, "code_file", "code_dir", sensitiveConfigurationKey, deletionProtectionKey, waitForActiveKey, acceptStatesKey)),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceReactorsRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	var nameRegex *regexp.Regexp
+	if value := data.Get("name_regex").(string); value != "" {
+		nameRegex = regexp.MustCompile(value)
+	}
+
+	reactors, err := listReactors(ctx, basisTheoryClient, &basistheory.ReactorsListRequest{})
+	if err != nil {
+		return apiErrorDiagnostics("Error listing Reactors:", err)
+	}
+
+	ids := make([]string, 0)
+	flattenedReactors := make([]interface{}, 0)
+	for _, reactor := range reactors {
+		if nameRegex != nil && !nameRegex.MatchString(getStringValue(reactor.Name)) {
+			continue
+		}
+
+		ids = append(ids, getStringValue(reactor.ID))
+		flattenedReactors = append(flattenedReactors, flattenReactor(reactor))
+	}
+
+	data.SetId(strconv.Itoa(schema.HashString(data.Get("name_regex").(string))))
+
+	if err := data.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := data.Set("reactors", flattenedReactors); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceReactors(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckReactorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceReactors, "terraform_test_reactors_data_source"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.basistheory_reactors.by_name", "reactors.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_reactors.by_name", "ids.0",
+						"basistheory_reactor.terraform_test_reactors_data_source", "id"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_reactors.by_name", "reactors.0.code",
+						"basistheory_reactor.terraform_test_reactors_data_source", "code"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceReactors = `
+resource "basistheory_reactor" "%[1]s" {
+  name = "(Deletable) Terraform reactors data source %[1]s"
+  code = <<-EOT
+            module.exports = async function (context) {
+              return context;
+            };
+        EOT
+}
+
+data "basistheory_reactors" "by_name" {
+  name_regex = "reactors data source %[1]s$"
+
+  depends_on = [basistheory_reactor.%[1]s]
+}
+`
@@ -0,0 +1,160 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBasisTheoryTokens() *schema.Resource {
+	return &schema.Resource{
+		Description: "Searches the Tokens in the Tenant https://developers.basistheory.com/docs/api/tokens/search. Only non-sensitive attributes are returned; Token data is never stored in state",
+
+		ReadContext: dataSourceTokensRead,
+
+		Schema: map[string]*schema.Schema{
+			"query": {
+				Description:  "Search query, such as `metadata.purpose:upstream-api-key metadata.env:prod`. See https://developers.basistheory.com/docs/api/tokens/search for the syntax",
+				Type:         schema.TypeString,
+				Required:     true,
+				ValidateFunc: validation.StringIsNotWhiteSpace,
+			},
+			"page": {
+				Description:  "Page of results to return, starting at 1. Defaults to 1",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      1,
+				ValidateFunc: validation.IntAtLeast(1),
+			},
+			"size": {
+				Description:  "Number of Tokens per page, up to 100. Defaults to 20",
+				Type:         schema.TypeInt,
+				Optional:     true,
+				Default:      20,
+				ValidateFunc: validation.IntBetween(1, 100),
+			},
+			"ids": {
+				Description: "Identifiers of the matching Tokens",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"tokens": {
+				Description: "The matching Tokens",
+				Type:        schema.TypeList,
+				Computed:    true,
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Description: "Unique identifier of the Token",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"type": {
+							Description: "Type of the Token",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"metadata": {
+							Description: "Non-sensitive key/value pairs stored with the Token",
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"containers": {
+							Description: "Containers the Token belongs to",
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"fingerprint": {
+							Description: "Fingerprint of the Token's data",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"mask": {
+							Description: "Expression used to mask the Token's data",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+						"created_at": {
+							Description: "Timestamp at which the Token was created",
+							Type:        schema.TypeString,
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceTokensRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	query := data.Get("query").(string)
+	page := data.Get("page").(int)
+	size := data.Get("size").(int)
+
+	results, err := basisTheoryClient.Tokens.Search(ctx, &basistheory.SearchTokensRequest{
+		Query: &query,
+		Page:  &page,
+		Size:  &size,
+	})
+	if err != nil {
+		return apiErrorDiagnostics("Error searching Tokens:", err)
+	}
+
+	ids := make([]string, 0)
+	flattenedTokens := make([]interface{}, 0)
+	for _, token := range results.Results {
+		ids = append(ids, getStringValue(token.ID))
+		flattenedTokens = append(flattenedTokens, flattenSearchedToken(token))
+	}
+
+	data.SetId(strconv.Itoa(schema.HashString(fmt.Sprintf("%s|%d|%d", query, page, size))))
+
+	if err := data.Set("ids", ids); err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err := data.Set("tokens", flattenedTokens); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+// flattenSearchedToken returns the Token's non-sensitive attributes, leaving
+// out its data even when the API key can reveal it.
+func flattenSearchedToken(token *basistheory.Token) map[string]interface{} {
+	createdAt := ""
+	if token.CreatedAt != nil {
+		createdAt = token.CreatedAt.Format(time.RFC3339)
+	}
+
+	mask, _ := token.Mask.(string)
+
+	return map[string]interface{}{
+		"id":          getStringValue(token.ID),
+		"type":        getStringValue(token.Type),
+		"metadata":    flattenTokenMetadata(token.Metadata),
+		"containers":  token.Containers,
+		"fingerprint": getStringValue(token.Fingerprint),
+		"mask":        mask,
+		"created_at":  createdAt,
+	}
+}
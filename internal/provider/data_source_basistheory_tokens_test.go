@@ -0,0 +1,104 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestDataSourceTokens(t *testing.T) {
+	purpose := "terraform-test-" + newFakeUUID()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceTokens, purpose),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.basistheory_tokens.prod", "tokens.#", "1"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_tokens.prod", "ids.0",
+						"basistheory_token.prod", "id"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_tokens.prod", "tokens.0.metadata.env", "prod"),
+					resource.TestCheckResourceAttrPair(
+						"data.basistheory_tokens.prod", "tokens.0.fingerprint",
+						"basistheory_token.prod", "fingerprint"),
+					resource.TestCheckNoResourceAttr(
+						"data.basistheory_tokens.prod", "tokens.0.data"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_tokens.all_environments", "tokens.#", "2"),
+					resource.TestCheckResourceAttr(
+						"data.basistheory_tokens.second_page", "tokens.#", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenSearchedToken_leavesOutData(t *testing.T) {
+	id, fingerprint, env := "token-id", "fingerprint", "prod"
+
+	actual := flattenSearchedToken(&basistheory.Token{
+		ID:          &id,
+		Data:        "sk_live_secret",
+		Fingerprint: &fingerprint,
+		Metadata:    map[string]*string{"env": &env},
+		Mask:        "{{ data | reveal_last: 4 }}",
+	})
+
+	if _, ok := actual["data"]; ok {
+		t.Fatalf("expected data to be left out, got %v", actual)
+	}
+	if actual["id"] != id || actual["fingerprint"] != fingerprint || actual["mask"] != "{{ data | reveal_last: 4 }}" {
+		t.Fatalf("unexpected flattened token %v", actual)
+	}
+	if metadata := actual["metadata"].(map[string]interface{}); metadata["env"] != env {
+		t.Fatalf("expected metadata to be flattened, got %v", metadata)
+	}
+}
+
+const testAccDataSourceTokens = `
+resource "basistheory_token" "prod" {
+  type = "token"
+  data = "sk_test_prod"
+  metadata = {
+    purpose = "%[1]s"
+    env     = "prod"
+  }
+}
+
+resource "basistheory_token" "staging" {
+  type = "token"
+  data = "sk_test_staging"
+  metadata = {
+    purpose = "%[1]s"
+    env     = "staging"
+  }
+}
+
+data "basistheory_tokens" "prod" {
+  query = "metadata.purpose:%[1]s metadata.env:prod"
+
+  depends_on = [basistheory_token.prod, basistheory_token.staging]
+}
+
+data "basistheory_tokens" "all_environments" {
+  query = "metadata.purpose:%[1]s"
+
+  depends_on = [basistheory_token.prod, basistheory_token.staging]
+}
+
+data "basistheory_tokens" "second_page" {
+  query = "metadata.purpose:%[1]s"
+  page  = 2
+  size  = 1
+
+  depends_on = [basistheory_token.prod, basistheory_token.staging]
+}
+`
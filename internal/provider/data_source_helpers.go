@@ -0,0 +1,65 @@
+package provider
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// dataSourceSchemaFromResourceSchema copies a resource schema into its data
+// source counterpart, marking every attribute as computed so the data source
+// exposes exactly the same shape as the resource state. Excluded attributes
+// are dropped at every level of nesting.
+func dataSourceSchemaFromResourceSchema(resourceSchema map[string]*schema.Schema, excluded ...string) map[string]*schema.Schema {
+	dataSourceSchema := make(map[string]*schema.Schema, len(resourceSchema))
+
+	for name, attribute := range resourceSchema {
+		if containsString(excluded, name) {
+			continue
+		}
+
+		dataSourceSchema[name] = dataSourceAttributeFromResourceAttribute(attribute, excluded...)
+	}
+
+	return dataSourceSchema
+}
+
+func dataSourceAttributeFromResourceAttribute(attribute *schema.Schema, excluded ...string) *schema.Schema {
+	dataSourceAttribute := &schema.Schema{
+		Type:        attribute.Type,
+		Description: attribute.Description,
+		Computed:    true,
+		Sensitive:   attribute.Sensitive,
+	}
+
+	switch elem := attribute.Elem.(type) {
+	case *schema.Resource:
+		dataSourceAttribute.Elem = &schema.Resource{
+			Schema: dataSourceSchemaFromResourceSchema(elem.Schema, excluded...),
+		}
+	case *schema.Schema:
+		dataSourceAttribute.Elem = &schema.Schema{Type: elem.Type}
+	}
+
+	return dataSourceAttribute
+}
+
+func multipleMatchesDiagnostics(resourceName string, pluralResourceName string, name string, ids []string) diag.Diagnostics {
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity: diag.Error,
+		Summary:  fmt.Sprintf("Multiple %s found", pluralResourceName),
+		Detail:   fmt.Sprintf("Found %d %s named %q (%s). Look up the %s by id instead.", len(ids), pluralResourceName, name, strings.Join(ids, ", "), resourceName),
+	}}
+}
+
+func containsString(values []string, value string) bool {
+	for _, candidate := range values {
+		if candidate == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,142 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDataSourceSchemaFromResourceSchema(t *testing.T) {
+	dataSourceSchema := dataSourceSchemaFromResourceSchema(resourceBasisTheoryProxy().Schema, "encrypted")
+
+	if _, ok := dataSourceSchema["encrypted"]; ok {
+		t.Fatalf("expected excluded attribute to be dropped")
+	}
+
+	if !dataSourceSchema["key"].Sensitive {
+		t.Fatalf("expected key to stay sensitive")
+	}
+
+	var assertComputed func(path string, attributes map[string]*schema.Schema)
+	assertComputed = func(path string, attributes map[string]*schema.Schema) {
+		for name, attribute := range attributes {
+			if !attribute.Computed || attribute.Optional || attribute.Required {
+				t.Fatalf("expected %s%s to be computed only", path, name)
+			}
+			if attribute.Default != nil || attribute.MaxItems != 0 || attribute.DiffSuppressFunc != nil {
+				t.Fatalf("expected %s%s to drop resource-only settings", path, name)
+			}
+			if elem, ok := attribute.Elem.(*schema.Resource); ok {
+				assertComputed(path+name+".", elem.Schema)
+			}
+		}
+	}
+	assertComputed("", dataSourceSchema)
+
+	if err := (&schema.Resource{Schema: dataSourceSchema}).InternalValidate(nil, false); err != nil {
+		t.Fatalf("expected a valid data source schema, got %s", err)
+	}
+}
+
+// testAccDataSourceLookupsConfig creates a basistheory_<resourceType> with
+// attributes and looks it up by id and by name.
+func testAccDataSourceLookupsConfig(resourceType string, resourceName string, attributes string) string {
+	return fmt.Sprintf(`
+resource "basistheory_%[1]s" "%[2]s" {
+  name = "(Deletable) Terraform %[1]s data source %[2]s"
+%[3]s
+}
+
+data "basistheory_%[1]s" "by_id" {
+  id = basistheory_%[1]s.%[2]s.id
+}
+
+data "basistheory_%[1]s" "by_name" {
+  name = basistheory_%[1]s.%[2]s.name
+}
+`, resourceType, resourceName, attributes)
+}
+
+// testAccCheckDataSourceLookups checks that both lookups of
+// testAccDataSourceLookupsConfig found the resource.
+func testAccCheckDataSourceLookups(resourceType string, resourceName string) resource.TestCheckFunc {
+	address := fmt.Sprintf("basistheory_%s.%s", resourceType, resourceName)
+
+	return resource.ComposeAggregateTestCheckFunc(
+		resource.TestCheckResourceAttrPair("data.basistheory_"+resourceType+".by_id", "id", address, "id"),
+		resource.TestCheckResourceAttrPair("data.basistheory_"+resourceType+".by_name", "id", address, "id"),
+	)
+}
+
+// testDataSourceReadAgainstFakeAPI looks up objects the fake API creates in
+// collection from body, a JSON template taking the object's name and setting
+// configuration.API_KEY. It checks lookups by id and by name, and that missing
+// objects and names shared by several objects are reported.
+func testDataSourceReadAgainstFakeAPI(t *testing.T, dataSource *schema.Resource, resourceName string, pluralResourceName string, collection string, body string) {
+	fake := fakeAPIOnly(t)
+	meta := map[string]interface{}{
+		"client": newTestAPIClient(),
+	}
+
+	create := func(name string) string {
+		created := fakeRequest(t, fake, http.MethodPost, "/"+collection, fmt.Sprintf(body, name), http.StatusCreated)
+		id := created["id"].(string)
+		t.Cleanup(func() {
+			fakeRequest(t, fake, http.MethodDelete, "/"+collection+"/"+id, "", http.StatusNoContent)
+		})
+
+		return id
+	}
+	read := func(config map[string]interface{}) (*schema.ResourceData, diag.Diagnostics) {
+		data := schema.TestResourceDataRaw(t, dataSource.Schema, config)
+
+		return data, dataSource.ReadContext(context.Background(), data, meta)
+	}
+
+	name := "Terraform lookup " + newFakeUUID()
+	id := create(name)
+	for lookup, config := range map[string]map[string]interface{}{
+		"id":   {"id": id},
+		"name": {"name": name},
+	} {
+		data, diags := read(config)
+		if diags.HasError() {
+			t.Fatalf("%s: unexpected diagnostics: %v", lookup, diags)
+		}
+		if data.Id() != id {
+			t.Fatalf("%s: expected id %s, got %s", lookup, id, data.Id())
+		}
+		if actual := data.Get("configuration.API_KEY").(string); actual != "key_abcd1234" {
+			t.Fatalf("%s: expected the merged configuration, got %q", lookup, actual)
+		}
+	}
+
+	missing := newFakeUUID()
+	for lookup, testCase := range map[string]struct {
+		config   map[string]interface{}
+		expected string
+	}{
+		"missing id":   {map[string]interface{}{"id": missing}, fmt.Sprintf("%s %s not found", resourceName, missing)},
+		"missing name": {map[string]interface{}{"name": "Terraform missing " + missing}, fmt.Sprintf("No %s found with name", resourceName)},
+	} {
+		if _, diags := read(testCase.config); !diags.HasError() || !strings.Contains(diags[0].Summary, testCase.expected) {
+			t.Fatalf("%s: expected %q, got %v", lookup, testCase.expected, diags)
+		}
+	}
+
+	duplicated := "Terraform duplicate " + newFakeUUID()
+	first, second := create(duplicated), create(duplicated)
+	_, diags := read(map[string]interface{}{"name": duplicated})
+	if !diags.HasError() || diags[0].Summary != fmt.Sprintf("Multiple %s found", pluralResourceName) {
+		t.Fatalf("expected multiple matches to be reported, got %v", diags)
+	}
+	if !strings.Contains(diags[0].Detail, first) || !strings.Contains(diags[0].Detail, second) {
+		t.Fatalf("expected both ids in %q", diags[0].Detail)
+	}
+}
@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const deletionProtectionKey = "deletion_protection"
+
+func deletionProtectionSchema(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Prevents the %s from being destroyed or replaced while `true`. Set it to `false` in a separate apply before destroying the %s. Defaults to `false`", resourceName, resourceName),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     false,
+	}
+}
+
+// deletionProtectionDiagnostics refuses to delete a protected resource. The
+// value comes from state, so turning protection off in the same apply that
+// destroys or replaces the resource is not enough.
+func deletionProtectionDiagnostics(data *schema.ResourceData, resourceName string) diag.Diagnostics {
+	if !data.Get(deletionProtectionKey).(bool) {
+		return nil
+	}
+
+	return diag.Diagnostics{diag.Diagnostic{
+		Severity:      diag.Error,
+		Summary:       fmt.Sprintf("%s %s is protected from deletion", resourceName, data.Id()),
+		Detail:        fmt.Sprintf("deletion_protection is true. Set it to false and apply that change before destroying or replacing this %s.", resourceName),
+		AttributePath: cty.GetAttrPath(deletionProtectionKey),
+	}}
+}
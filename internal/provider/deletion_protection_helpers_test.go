@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestDeletionProtection_DeleteRefusesProtectedResources(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		resource *schema.Resource
+		delete   func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics
+	}{
+		"Application": {resourceBasisTheoryApplication(), resourceApplicationDelete},
+		"Proxy":       {resourceBasisTheoryProxy(), resourceProxyDelete},
+		"Reactor":     {resourceBasisTheoryReactor(), resourceReactorDelete},
+	} {
+		data := testCase.resource.Data(nil)
+		data.SetId("protected-id")
+		if err := data.Set(deletionProtectionKey, true); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		diags := testCase.delete(context.Background(), data, nil)
+
+		if !diags.HasError() {
+			t.Fatalf("%s: expected delete to be refused", name)
+		}
+		if expected := name + " protected-id is protected from deletion"; diags[0].Summary != expected {
+			t.Fatalf("%s: expected summary %q, got %q", name, expected, diags[0].Summary)
+		}
+		if !diags[0].AttributePath.Equals(cty.GetAttrPath(deletionProtectionKey)) {
+			t.Fatalf("%s: expected diagnostic on deletion_protection, got %#v", name, diags[0].AttributePath)
+		}
+	}
+}
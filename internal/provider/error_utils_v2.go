@@ -4,10 +4,19 @@ import (
 	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	basistheorycore "github.com/Basis-Theory/go-sdk/v7/core"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
 )
 
+var propertyPathSegmentPattern = regexp.MustCompile(`\[\d+\]|[^.\[\]]+`)
+
 func apiErrorDiagnostics(message string, err error) diag.Diagnostics {
 	var errorArgs []interface{}
 
@@ -31,6 +40,174 @@ func apiErrorDiagnostics(message string, err error) diag.Diagnostics {
 	return diag.Errorf(message, errorArgs...)
 }
 
+// apiErrorDiagnosticsWithAttributePaths behaves like apiErrorDiagnostics, but
+// reports each validation error whose property maps onto resourceSchema as its
+// own diagnostic with an AttributePath, so Terraform can point at the offending
+// configuration. Properties that cannot be mapped stay in the resource-level
+// error.
+func apiErrorDiagnosticsWithAttributePaths(message string, err error, resourceSchema map[string]*schema.Schema) diag.Diagnostics {
+	badRequestError, ok := err.(*basistheory.BadRequestError)
+	if !ok || badRequestError.Body == nil || len(badRequestError.Body.Errors) == 0 {
+		return apiErrorDiagnostics(message, err)
+	}
+
+	propertyNames := make([]string, 0, len(badRequestError.Body.Errors))
+	for propertyName := range badRequestError.Body.Errors {
+		propertyNames = append(propertyNames, propertyName)
+	}
+	sort.Strings(propertyNames)
+
+	var attributeDiagnostics diag.Diagnostics
+	unmappedErrors := map[string][]string{}
+
+	for _, propertyName := range propertyNames {
+		propertyErrors := badRequestError.Body.Errors[propertyName]
+		attributePath := attributePathFromPropertyName(propertyName, resourceSchema)
+
+		if attributePath == nil {
+			unmappedErrors[propertyName] = propertyErrors
+			continue
+		}
+
+		attributeDiagnostics = append(attributeDiagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("%s %s", message, propertyName),
+			Detail:        strings.Join(propertyErrors, "\n"),
+			AttributePath: attributePath,
+		})
+	}
+
+	details := *badRequestError.Body
+	details.Errors = unmappedErrors
+
+	var errorArgs []interface{}
+	message, errorArgs = processValidationProblemDetails(&details, message, errorArgs)
+
+	return append(diag.Errorf(message, errorArgs...), attributeDiagnostics...)
+}
+
+// attributePathFromPropertyName translates an API property name such as
+// requestTransforms[0].options.token into the matching attribute path
+// (request_transforms.0.options.0.token). Single-item blocks get an implicit
+// 0 index, and the path stops at attributes that cannot be addressed any
+// deeper (sets, maps and primitives holding JSON). It returns nil when the
+// property does not name an attribute of the schema.
+func attributePathFromPropertyName(propertyName string, resourceSchema map[string]*schema.Schema) cty.Path {
+	segments := propertyPathSegmentPattern.FindAllString(strings.TrimPrefix(propertyName, "$"), -1)
+
+	var path cty.Path
+	attributes := resourceSchema
+
+	for i := 0; i < len(segments) && attributes != nil; i++ {
+		attributeName := toSnakeCase(segments[i])
+		attribute, ok := attributes[attributeName]
+		if !ok {
+			// Repeated blocks are named in the singular (rules -> rule).
+			attributeName = strings.TrimSuffix(attributeName, "s")
+			if attribute, ok = attributes[attributeName]; !ok {
+				return nil
+			}
+		}
+
+		path = path.GetAttr(attributeName)
+		attributes = nil
+
+		hasNext := i+1 < len(segments)
+
+		switch attribute.Type {
+		case schema.TypeList:
+			index, isIndex := 0, false
+			if hasNext {
+				index, isIndex = parsePropertyIndex(segments[i+1])
+			}
+
+			if isIndex {
+				i++
+			} else if attribute.MaxItems != 1 || !hasNext {
+				continue
+			}
+
+			path = path.IndexInt(index)
+			if elem, ok := attribute.Elem.(*schema.Resource); ok {
+				attributes = elem.Schema
+			}
+		case schema.TypeMap:
+			if hasNext {
+				if _, isIndex := parsePropertyIndex(segments[i+1]); !isIndex {
+					path = path.IndexString(segments[i+1])
+				}
+			}
+		}
+	}
+
+	return path
+}
+
+// remapAttributePaths replaces the AttributePath of each diagnostic with the
+// one remap returns, for attributes the API reports under a single property,
+// such as configuration and sensitive_configuration.
+func remapAttributePaths(diags diag.Diagnostics, remap func(cty.Path) cty.Path) diag.Diagnostics {
+	for i := range diags {
+		if diags[i].AttributePath != nil {
+			diags[i].AttributePath = remap(diags[i].AttributePath)
+		}
+	}
+
+	return diags
+}
+
+// attributePathKey returns the ResourceData key of path, such as
+// request_transforms.0.options.0.token.
+func attributePathKey(path cty.Path) string {
+	keys := make([]string, 0, len(path))
+	for _, step := range path {
+		switch step := step.(type) {
+		case cty.GetAttrStep:
+			keys = append(keys, step.Name)
+		case cty.IndexStep:
+			if step.Key.Type() == cty.String {
+				keys = append(keys, step.Key.AsString())
+			} else {
+				index, _ := step.Key.AsBigFloat().Int64()
+				keys = append(keys, strconv.FormatInt(index, 10))
+			}
+		}
+	}
+
+	return strings.Join(keys, ".")
+}
+
+func parsePropertyIndex(segment string) (int, bool) {
+	if !strings.HasPrefix(segment, "[") {
+		return 0, false
+	}
+
+	index, err := strconv.Atoi(strings.Trim(segment, "[]"))
+	if err != nil {
+		return 0, false
+	}
+
+	return index, true
+}
+
+func toSnakeCase(value string) string {
+	runes := []rune(value)
+
+	var builder strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			previous := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(previous) || unicode.IsDigit(previous) || (unicode.IsUpper(previous) && nextIsLower) {
+				builder.WriteRune('_')
+			}
+		}
+		builder.WriteRune(unicode.ToLower(r))
+	}
+
+	return builder.String()
+}
+
 func unknownError(message string, err error, errorArgs []interface{}) (string, []interface{}) {
 	if err == nil {
 		message += "\n\tUnknown Error: (unavailable)"
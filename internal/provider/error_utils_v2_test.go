@@ -4,7 +4,9 @@ import (
 	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	"github.com/Basis-Theory/go-sdk/v7/core"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -245,3 +247,141 @@ func TestErrorUtils_apiErrorDiagnostics_shouldHandleNilError(t *testing.T) {
 	assert.Equal(t, expected+"\n\tUnknown Error: (unavailable)", actual[0].Summary)
 	assert.Equal(t, diag.Error, actual[0].Severity)
 }
+
+func TestErrorUtils_apiErrorDiagnosticsWithAttributePaths_shouldMapValidationErrorsToAttributes(t *testing.T) {
+	var apiError = &basistheory.BadRequestError{
+		Body: &basistheory.ValidationProblemDetails{
+			Status: getIntPointer(400),
+			Title:  getStringPointer("One or more validation errors occurred."),
+			Detail: getStringPointer("Invalid Proxy"),
+			Errors: map[string][]string{
+				"requestTransforms[0].options.token": {"Token is invalid"},
+				"destinationUrl":                     {"Must be https", "Must be reachable"},
+				"unknownProperty":                    {"Unknown error"},
+			},
+		},
+		APIError: &core.APIError{StatusCode: 400},
+	}
+
+	actual := apiErrorDiagnosticsWithAttributePaths("Error creating Proxy:", apiError, resourceBasisTheoryProxy().Schema)
+
+	assert.Len(t, actual, 3)
+	assert.Equal(t, "Error creating Proxy:\n\tStatus Code: 400\n\tTitle: One or more validation errors occurred.\n\tDetail: Invalid Proxy\n\tErrors:\n\t\tunknownProperty: [Unknown error]", actual[0].Summary)
+	assert.Nil(t, actual[0].AttributePath)
+
+	assert.Equal(t, "Error creating Proxy: destinationUrl", actual[1].Summary)
+	assert.Equal(t, "Must be https\nMust be reachable", actual[1].Detail)
+	assert.Equal(t, cty.GetAttrPath("destination_url"), actual[1].AttributePath)
+
+	assert.Equal(t, "Error creating Proxy: requestTransforms[0].options.token", actual[2].Summary)
+	assert.Equal(t, cty.GetAttrPath("request_transforms").IndexInt(0).GetAttr("options").IndexInt(0).GetAttr("token"), actual[2].AttributePath)
+	assert.Equal(t, diag.Error, actual[2].Severity)
+}
+
+func TestErrorUtils_apiErrorDiagnosticsWithAttributePaths_shouldFallBackWithoutValidationErrors(t *testing.T) {
+	var apiError = &basistheory.ConflictError{
+		Body: &basistheory.ProblemDetails{
+			Status: getIntPointer(409),
+			Title:  getStringPointer("Conflict"),
+			Detail: getStringPointer("Already exists"),
+		},
+		APIError: &core.APIError{StatusCode: 409},
+	}
+
+	actual := apiErrorDiagnosticsWithAttributePaths("Error creating Proxy:", apiError, resourceBasisTheoryProxy().Schema)
+
+	assert.Equal(t, apiErrorDiagnostics("Error creating Proxy:", apiError), actual)
+}
+
+func TestErrorUtils_attributePathFromPropertyName(t *testing.T) {
+	proxySchema := resourceBasisTheoryProxy().Schema
+	reactorSchema := resourceBasisTheoryReactor().Schema
+	applicationSchema := resourceBasisTheoryApplication().Schema
+
+	testCases := []struct {
+		propertyName   string
+		resourceSchema map[string]*schema.Schema
+		expected       cty.Path
+	}{
+		{"name", proxySchema, cty.GetAttrPath("name")},
+		{"Name", proxySchema, cty.GetAttrPath("name")},
+		{"$.destinationUrl", proxySchema, cty.GetAttrPath("destination_url")},
+		{"requestTransforms[1].options.runtime.image", proxySchema, cty.GetAttrPath("request_transforms").IndexInt(1).GetAttr("options").IndexInt(0).GetAttr("runtime").IndexInt(0).GetAttr("image")},
+		{"responseTransforms[0].options.token.type", proxySchema, cty.GetAttrPath("response_transforms").IndexInt(0).GetAttr("options").IndexInt(0).GetAttr("token")},
+		{"requestTransforms", proxySchema, cty.GetAttrPath("request_transforms")},
+		{"requestTransforms.code", proxySchema, cty.GetAttrPath("request_transforms")},
+		{"configuration.API_KEY", proxySchema, cty.GetAttrPath("configuration").IndexString("API_KEY")},
+		{"runtime.warmConcurrency", reactorSchema, cty.GetAttrPath("runtime").IndexInt(0).GetAttr("warm_concurrency")},
+		{"runtime.permissions[2]", reactorSchema, cty.GetAttrPath("runtime").IndexInt(0).GetAttr("permissions").IndexInt(2)},
+		{"rules[0].priority", applicationSchema, cty.GetAttrPath("rule")},
+		{"requestTransforms[0].unknown", proxySchema, nil},
+		{"application.id", reactorSchema, nil},
+		{"", proxySchema, nil},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, attributePathFromPropertyName(testCase.propertyName, testCase.resourceSchema), testCase.propertyName)
+	}
+}
+
+func TestErrorUtils_remapAttributePaths_proxyTokenTemplate(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryProxy().Schema, map[string]interface{}{
+		"name":            "Terraform proxy",
+		"destination_url": "https://httpbin.org/post",
+		"request_transforms": []interface{}{
+			map[string]interface{}{
+				"type":    "tokenize",
+				"options": []interface{}{map[string]interface{}{"token": `{"type":"token"}`}},
+			},
+			map[string]interface{}{
+				"type":    "tokenize",
+				"options": []interface{}{map[string]interface{}{"token_template": []interface{}{map[string]interface{}{"type": "card"}}}},
+			},
+		},
+	})
+	apiError := &basistheory.BadRequestError{
+		Body: &basistheory.ValidationProblemDetails{
+			Status: getIntPointer(400),
+			Title:  getStringPointer("One or more validation errors occurred."),
+			Detail: getStringPointer("Invalid Proxy"),
+			Errors: map[string][]string{
+				"requestTransforms[0].options.token":      {"Token is invalid"},
+				"requestTransforms[1].options.token.type": {"Type is invalid"},
+			},
+		},
+		APIError: &core.APIError{StatusCode: 400},
+	}
+
+	actual := remapAttributePaths(apiErrorDiagnosticsWithAttributePaths("Error creating Proxy:", apiError, resourceBasisTheoryProxy().Schema), proxyAttributePath(data))
+
+	assert.Len(t, actual, 3)
+	assert.Equal(t, cty.GetAttrPath("request_transforms").IndexInt(0).GetAttr("options").IndexInt(0).GetAttr("token"), actual[1].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("request_transforms").IndexInt(1).GetAttr("options").IndexInt(0).GetAttr("token_template").IndexInt(0), actual[2].AttributePath)
+}
+
+func TestErrorUtils_remapAttributePaths_sensitiveConfiguration(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryReactor().Schema, map[string]interface{}{
+		"name":                    "Terraform reactor",
+		"code":                    "module.exports = async function (context) { return context; };",
+		"configuration":           map[string]interface{}{"BASE_URL": "https://api.example.com"},
+		sensitiveConfigurationKey: map[string]interface{}{"API_KEY": "secret"},
+	})
+	apiError := &basistheory.BadRequestError{
+		Body: &basistheory.ValidationProblemDetails{
+			Status: getIntPointer(400),
+			Title:  getStringPointer("One or more validation errors occurred."),
+			Detail: getStringPointer("Invalid Reactor"),
+			Errors: map[string][]string{
+				"configuration.API_KEY":  {"API_KEY is invalid"},
+				"configuration.BASE_URL": {"BASE_URL is invalid"},
+			},
+		},
+		APIError: &core.APIError{StatusCode: 400},
+	}
+
+	actual := remapAttributePaths(apiErrorDiagnosticsWithAttributePaths("Error creating Reactor:", apiError, resourceBasisTheoryReactor().Schema), sensitiveConfigurationAttributePath(data))
+
+	assert.Len(t, actual, 3)
+	assert.Equal(t, cty.GetAttrPath(sensitiveConfigurationKey).IndexString("API_KEY"), actual[1].AttributePath)
+	assert.Equal(t, cty.GetAttrPath("configuration").IndexString("BASE_URL"), actual[2].AttributePath)
+}
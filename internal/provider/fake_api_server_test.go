@@ -0,0 +1,862 @@
+package provider
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testFakeAPI is the fake the acceptance tests run against when
+// BASISTHEORY_FAKE_API is set. It is nil when the tests target a live API.
+var testFakeAPI *fakeBasisTheoryAPI
+
+func TestMain(m *testing.M) {
+	if os.Getenv("BASISTHEORY_FAKE_API") == "" {
+		os.Exit(m.Run())
+	}
+
+	testFakeAPI = newFakeBasisTheoryAPI()
+
+	code := m.Run()
+	testFakeAPI.Close()
+	os.Exit(code)
+}
+
+// fakeAPIOnly skips tests that rely on the fake to simulate behavior a live
+// Tenant cannot be asked to reproduce, such as failed provisioning.
+func fakeAPIOnly(t *testing.T) *fakeBasisTheoryAPI {
+	if testFakeAPI == nil {
+		t.Skip("set BASISTHEORY_FAKE_API=1 to run this test against the in-memory API")
+	}
+
+	return testFakeAPI
+}
+
+const fakeAPIKey = "key_test_fake"
+
+// fakeWebhookSigningKey is the Tenant's Webhook signing key.
+const fakeWebhookSigningKey = "whsk_test_fake"
+
+// fakeCollections lists the resource collections the fake serves. Wildcards
+// match the id of the parent object, which must exist.
+var fakeCollections = []string{
+	"applications",
+	"applications/*/keys",
+	"proxies",
+	"reactors",
+	"tokens",
+	"webhooks",
+	"apple-pay/merchant-registration",
+	"apple-pay/merchant-registration/*/certificates",
+	"google-pay/merchant-registration",
+	"google-pay/merchant-registration/*/certificates",
+}
+
+// fakeRequiredFields mirrors the API's validation of create and update bodies.
+var fakeRequiredFields = map[string][]string{
+	"applications":                     {"name", "type"},
+	"proxies":                          {"name", "destination_url"},
+	"reactors":                         {"name", "code"},
+	"tokens":                           {"type", "data"},
+	"webhooks":                         {"name", "url", "events"},
+	"apple-pay/merchant-registration":  {"merchant_identifier"},
+	"google-pay/merchant-registration": {"merchant_identifier"},
+}
+
+// fakeWriteOnlyFields are accepted on create but never returned.
+var fakeWriteOnlyFields = []string{
+	"create_key",
+	"merchant_certificate_data",
+	"merchant_certificate_password",
+	"payment_processor_certificate_data",
+	"payment_processor_certificate_password",
+}
+
+// fakeBasisTheoryAPI is an in-memory stand-in for the Basis Theory API,
+// serving the endpoints used by the provider's resources and data sources.
+// Proxies and Reactors start in a provisioning state and settle after
+// ProvisioningPolls reads of the object, into "active" unless another state
+// was requested with setProvisioningOutcome.
+type fakeBasisTheoryAPI struct {
+	server *httptest.Server
+
+	mu                   sync.Mutex
+	objects              map[string]map[string]map[string]interface{}
+	order                map[string][]string
+	applePayDomains      []string
+	provisioning         map[string]*fakeProvisioning
+	provisioningOutcomes map[string]string
+
+	ProvisioningPolls int
+}
+
+type fakeProvisioning struct {
+	remainingPolls int
+	outcome        string
+}
+
+func newFakeBasisTheoryAPI() *fakeBasisTheoryAPI {
+	fake := &fakeBasisTheoryAPI{
+		objects:              map[string]map[string]map[string]interface{}{},
+		order:                map[string][]string{},
+		applePayDomains:      []string{},
+		provisioning:         map[string]*fakeProvisioning{},
+		provisioningOutcomes: map[string]string{},
+		ProvisioningPolls:    1,
+	}
+	fake.server = httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+
+	return fake
+}
+
+func (f *fakeBasisTheoryAPI) URL() string {
+	return f.server.URL
+}
+
+func (f *fakeBasisTheoryAPI) Close() {
+	f.server.Close()
+}
+
+// setProvisioningOutcome makes Proxies and Reactors with the given name settle
+// into state ("active", "failed" or "outdated") after provisioning.
+func (f *fakeBasisTheoryAPI) setProvisioningOutcome(name string, state string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.provisioningOutcomes[name] = state
+}
+
+func (f *fakeBasisTheoryAPI) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	if r.Header.Get("BT-API-KEY") == "" {
+		writeFakeProblem(w, http.StatusUnauthorized, "Unauthorized", nil)
+		return
+	}
+
+	path := strings.Trim(r.URL.Path, "/")
+	if path == "apple-pay/domain-registration" {
+		f.serveApplePayDomains(w, r)
+		return
+	}
+	if path == "permissions" {
+		f.servePermissions(w, r)
+		return
+	}
+	if path == "webhooks/signing-key" && r.Method == http.MethodGet {
+		writeFakeJSON(w, http.StatusOK, map[string]interface{}{"signing_key": fakeWebhookSigningKey})
+		return
+	}
+	if path == "tokens/search" {
+		f.searchTokens(w, r)
+		return
+	}
+
+	segments := strings.Split(path, "/")
+	collectionSegments, id := segments, ""
+	if len(segments)%2 == 0 {
+		collectionSegments, id = segments[:len(segments)-1], segments[len(segments)-1]
+	}
+
+	kind, ok := matchFakeCollection(collectionSegments)
+	if !ok {
+		writeFakeProblem(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+
+	if parentCollection, parentID := fakeParent(collectionSegments); parentCollection != "" {
+		if _, ok := f.objects[parentCollection][parentID]; !ok {
+			writeFakeProblem(w, http.StatusNotFound, "Not Found", nil)
+			return
+		}
+	}
+
+	collection := strings.Join(collectionSegments, "/")
+
+	switch {
+	case id == "" && r.Method == http.MethodGet:
+		f.list(w, r, collection)
+	case id == "" && r.Method == http.MethodPost:
+		f.create(w, r, kind, collection)
+	case id != "" && r.Method == http.MethodGet:
+		f.get(w, kind, collection, id)
+	case id != "" && (r.Method == http.MethodPut || r.Method == http.MethodPatch):
+		f.update(w, r, kind, collection, id)
+	case id != "" && r.Method == http.MethodDelete:
+		f.delete(w, collection, id)
+	default:
+		writeFakeProblem(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+	}
+}
+
+func (f *fakeBasisTheoryAPI) list(w http.ResponseWriter, r *http.Request, collection string) {
+	query := r.URL.Query()
+
+	data := []interface{}{}
+	for _, id := range f.order[collection] {
+		object := f.objects[collection][id]
+		if ids := query["id"]; len(ids) > 0 && !containsString(ids, id) {
+			continue
+		}
+		if types := query["type"]; len(types) > 0 && !containsString(types, fmt.Sprint(object["type"])) {
+			continue
+		}
+		if name := query.Get("name"); name != "" && !strings.Contains(strings.ToLower(fmt.Sprint(object["name"])), strings.ToLower(name)) {
+			continue
+		}
+		data = append(data, f.response(collection, object))
+	}
+
+	// Every match is served on the first page, so later pages are empty.
+	if page := query.Get("page"); (page != "" && page != "1") || query.Get("start") != "" {
+		data = []interface{}{}
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"pagination": map[string]interface{}{
+			"total_items": len(data),
+			"page_number": 1,
+			"page_size":   len(data),
+			"total_pages": 1,
+		},
+		"data": data,
+	})
+}
+
+func (f *fakeBasisTheoryAPI) create(w http.ResponseWriter, r *http.Request, kind string, collection string) {
+	body, ok := readFakeBody(w, r)
+	if !ok {
+		return
+	}
+
+	if validationErrors := validateFakeBody(kind, body); len(validationErrors) > 0 {
+		writeFakeProblem(w, http.StatusBadRequest, "One or more validation errors occurred.", validationErrors)
+		return
+	}
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	id := newFakeUUID()
+	object := map[string]interface{}{}
+	for key, value := range body {
+		object[key] = value
+	}
+	object["id"] = id
+	object["tenant_id"] = fakeTenantID
+	object["created_by"] = fakeActorID
+	object["created_at"] = now
+
+	created := map[string]interface{}{}
+
+	switch kind {
+	case "applications":
+		if createKey, _ := body["create_key"].(bool); createKey {
+			key := f.store("applications/"+id+"/keys", map[string]interface{}{
+				"id":         newFakeUUID(),
+				"created_by": fakeActorID,
+				"created_at": now,
+			})
+			createdKey := fakeKeyResponse(key)
+			createdKey["key"] = newFakeKey()
+			created["keys"] = []interface{}{createdKey}
+		}
+	case "applications/*/keys":
+		created["key"] = newFakeKey()
+	case "proxies":
+		object["key"] = newFakeKey()
+		if application, ok := body["application"].(map[string]interface{}); ok {
+			object["application_id"] = application["id"]
+			delete(object, "application")
+		}
+		if _, ok := object["require_auth"]; !ok {
+			object["require_auth"] = true
+		}
+		f.startProvisioning(id, object, "creating")
+	case "reactors":
+		f.startProvisioning(id, object, "creating")
+	case "tokens":
+		if tokenID, ok := body["id"].(string); ok && tokenID != "" {
+			if _, exists := f.objects[collection][tokenID]; exists {
+				writeFakeProblem(w, http.StatusConflict, "Conflict", nil)
+				return
+			}
+			object["id"] = tokenID
+		}
+		if _, ok := object["containers"]; !ok {
+			object["containers"] = []interface{}{"/general/high/"}
+		}
+		if _, ok := object["fingerprint_expression"]; !ok {
+			object["fingerprint_expression"] = "{{ data | stringify }}"
+		}
+		if _, ok := object["mask"]; !ok {
+			object["mask"] = "{{ data }}"
+		}
+		delete(object, "deduplicate_token")
+		object["fingerprint"] = strings.ReplaceAll(newFakeUUID(), "-", "")
+	case "webhooks":
+		object["status"] = "enabled"
+	case "apple-pay/merchant-registration/*/certificates", "google-pay/merchant-registration/*/certificates":
+		object["merchant_certificate_fingerprint"] = strings.ToUpper(strings.ReplaceAll(newFakeUUID(), "-", ""))
+		object["merchant_certificate_expiration_date"] = time.Now().UTC().AddDate(1, 0, 0).Format(time.RFC3339)
+	}
+
+	f.store(collection, object)
+
+	response := f.response(collection, object)
+	for key, value := range created {
+		response[key] = value
+	}
+
+	writeFakeJSON(w, http.StatusCreated, response)
+}
+
+func (f *fakeBasisTheoryAPI) get(w http.ResponseWriter, kind string, collection string, id string) {
+	object, ok := f.objects[collection][id]
+	if !ok {
+		writeFakeProblem(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+
+	if kind == "proxies" || kind == "reactors" {
+		f.advanceProvisioning(id, object)
+	}
+
+	writeFakeJSON(w, http.StatusOK, f.response(collection, object))
+}
+
+func (f *fakeBasisTheoryAPI) update(w http.ResponseWriter, r *http.Request, kind string, collection string, id string) {
+	object, ok := f.objects[collection][id]
+	if !ok {
+		writeFakeProblem(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+
+	body, ok := readFakeBody(w, r)
+	if !ok {
+		return
+	}
+
+	// Fields left out of an update keep their value, so validation runs on the
+	// merged object.
+	updated := map[string]interface{}{}
+	for key, value := range object {
+		updated[key] = value
+	}
+	for key, value := range body {
+		if value == nil {
+			delete(updated, key)
+			continue
+		}
+		updated[key] = value
+	}
+	if application, ok := body["application"].(map[string]interface{}); ok && kind == "proxies" {
+		updated["application_id"] = application["id"]
+		delete(updated, "application")
+	}
+
+	if validationErrors := validateFakeBody(kind, updated); len(validationErrors) > 0 {
+		writeFakeProblem(w, http.StatusBadRequest, "One or more validation errors occurred.", validationErrors)
+		return
+	}
+
+	object = updated
+	f.objects[collection][id] = object
+	object["modified_by"] = fakeActorID
+	object["modified_at"] = time.Now().UTC().Format(time.RFC3339)
+
+	if kind == "proxies" || kind == "reactors" {
+		f.startProvisioning(id, object, "updating")
+	}
+
+	writeFakeJSON(w, http.StatusOK, f.response(collection, object))
+}
+
+func (f *fakeBasisTheoryAPI) delete(w http.ResponseWriter, collection string, id string) {
+	if _, ok := f.objects[collection][id]; !ok {
+		writeFakeProblem(w, http.StatusNotFound, "Not Found", nil)
+		return
+	}
+
+	delete(f.objects[collection], id)
+	delete(f.provisioning, id)
+	for i, candidate := range f.order[collection] {
+		if candidate == id {
+			f.order[collection] = append(f.order[collection][:i:i], f.order[collection][i+1:]...)
+			break
+		}
+	}
+
+	// Children, like an Application's keys, go away with their parent.
+	for child := range f.objects {
+		if strings.HasPrefix(child, collection+"/"+id+"/") {
+			delete(f.objects, child)
+			delete(f.order, child)
+		}
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (f *fakeBasisTheoryAPI) serveApplePayDomains(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut:
+		body, ok := readFakeBody(w, r)
+		if !ok {
+			return
+		}
+
+		domains, ok := body["domains"].([]interface{})
+		if !ok {
+			writeFakeProblem(w, http.StatusBadRequest, "One or more validation errors occurred.", map[string][]string{
+				"domains": {"The domains field is required."},
+			})
+			return
+		}
+
+		f.applePayDomains = []string{}
+		for _, domain := range domains {
+			if !containsString(f.applePayDomains, fmt.Sprint(domain)) {
+				f.applePayDomains = append(f.applePayDomains, fmt.Sprint(domain))
+			}
+		}
+	default:
+		writeFakeProblem(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+		return
+	}
+
+	domains := []interface{}{}
+	for _, domain := range f.applePayDomains {
+		domains = append(domains, map[string]interface{}{
+			"domain":            domain,
+			"validated_at":      time.Now().UTC().Format(time.RFC3339),
+			"validation_status": "validated",
+		})
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{"domains": domains})
+}
+
+// servePermissions lists the provider's embedded permission catalog, since the
+// fake grants every permission the API knows about.
+func (f *fakeBasisTheoryAPI) servePermissions(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeFakeProblem(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+		return
+	}
+
+	applicationTypes := map[string][]string{}
+	var permissionTypes []string
+	for applicationType, permissions := range defaultPermissionCatalog {
+		for _, permission := range permissions {
+			if _, ok := applicationTypes[permission]; !ok {
+				permissionTypes = append(permissionTypes, permission)
+			}
+			applicationTypes[permission] = append(applicationTypes[permission], applicationType)
+		}
+	}
+	sort.Strings(permissionTypes)
+
+	applicationType := r.URL.Query().Get("application_type")
+	permissions := []interface{}{}
+	for _, permission := range permissionTypes {
+		sort.Strings(applicationTypes[permission])
+		if applicationType != "" && !containsString(applicationTypes[permission], applicationType) {
+			continue
+		}
+		permissions = append(permissions, map[string]interface{}{
+			"type":              permission,
+			"description":       "Grants " + permission,
+			"application_types": applicationTypes[permission],
+		})
+	}
+
+	writeFakeJSON(w, http.StatusOK, permissions)
+}
+
+// searchTokens supports queries made of space separated field:value terms, all
+// of which must match, on id, type and metadata.<key>.
+func (f *fakeBasisTheoryAPI) searchTokens(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeFakeProblem(w, http.StatusMethodNotAllowed, "Method Not Allowed", nil)
+		return
+	}
+
+	body, ok := readFakeBody(w, r)
+	if !ok {
+		return
+	}
+
+	query, _ := body["query"].(string)
+	page, size := 1, 20
+	if value, ok := body["page"].(float64); ok {
+		page = int(value)
+	}
+	if value, ok := body["size"].(float64); ok {
+		size = int(value)
+	}
+
+	matches := []interface{}{}
+	for _, id := range f.order["tokens"] {
+		token := f.objects["tokens"][id]
+		if fakeTokenMatches(token, query) {
+			matches = append(matches, f.response("tokens", token))
+		}
+	}
+
+	start, end := (page-1)*size, page*size
+	if start > len(matches) {
+		start = len(matches)
+	}
+	if end > len(matches) {
+		end = len(matches)
+	}
+
+	writeFakeJSON(w, http.StatusOK, map[string]interface{}{
+		"pagination": map[string]interface{}{
+			"total_items": len(matches),
+			"page_number": page,
+			"page_size":   size,
+			"total_pages": (len(matches) + size - 1) / size,
+		},
+		"data": matches[start:end],
+	})
+}
+
+func fakeTokenMatches(token map[string]interface{}, query string) bool {
+	for _, term := range strings.Fields(query) {
+		field, value, ok := strings.Cut(term, ":")
+		if !ok {
+			return false
+		}
+
+		var actual interface{}
+		if key, isMetadata := strings.CutPrefix(field, "metadata."); isMetadata {
+			metadata, _ := token["metadata"].(map[string]interface{})
+			actual = metadata[key]
+		} else {
+			actual = token[field]
+		}
+
+		if actual == nil || fmt.Sprint(actual) != strings.Trim(value, `"`) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (f *fakeBasisTheoryAPI) store(collection string, object map[string]interface{}) map[string]interface{} {
+	if f.objects[collection] == nil {
+		f.objects[collection] = map[string]map[string]interface{}{}
+	}
+
+	id := object["id"].(string)
+	f.objects[collection][id] = object
+	f.order[collection] = append(f.order[collection], id)
+
+	return object
+}
+
+// response copies an object without the fields the API never returns.
+func (f *fakeBasisTheoryAPI) response(collection string, object map[string]interface{}) map[string]interface{} {
+	response := map[string]interface{}{}
+	for key, value := range object {
+		if !containsString(fakeWriteOnlyFields, key) {
+			response[key] = value
+		}
+	}
+
+	if strings.HasSuffix(collection, "/keys") {
+		return fakeKeyResponse(response)
+	}
+
+	return response
+}
+
+func (f *fakeBasisTheoryAPI) startProvisioning(id string, object map[string]interface{}, state string) {
+	outcome, ok := f.provisioningOutcomes[fmt.Sprint(object["name"])]
+	if !ok {
+		outcome = "active"
+	}
+
+	object["state"] = state
+	delete(object, "requested")
+	f.provisioning[id] = &fakeProvisioning{
+		remainingPolls: f.ProvisioningPolls,
+		outcome:        outcome,
+	}
+	if f.ProvisioningPolls <= 0 {
+		f.advanceProvisioning(id, object)
+	}
+}
+
+func (f *fakeBasisTheoryAPI) advanceProvisioning(id string, object map[string]interface{}) {
+	provisioning, ok := f.provisioning[id]
+	if !ok {
+		return
+	}
+
+	if provisioning.remainingPolls > 0 {
+		provisioning.remainingPolls--
+		return
+	}
+
+	object["state"] = provisioning.outcome
+	if provisioning.outcome != "active" {
+		object["requested"] = map[string]interface{}{
+			"error_code":    "provisioning_" + provisioning.outcome,
+			"error_message": fmt.Sprintf("Simulated %s provisioning", provisioning.outcome),
+			"error_details": map[string]interface{}{"source": "fake"},
+		}
+	}
+	delete(f.provisioning, id)
+}
+
+const (
+	fakeTenantID = "8a1f3c5e-2b4d-4e6f-9a0b-1c2d3e4f5a6b"
+	fakeActorID  = "0f9e8d7c-6b5a-4c3d-8e1f-2a3b4c5d6e7f"
+)
+
+func matchFakeCollection(segments []string) (string, bool) {
+	for _, pattern := range fakeCollections {
+		patternSegments := strings.Split(pattern, "/")
+		if len(patternSegments) != len(segments) {
+			continue
+		}
+
+		matches := true
+		for i, patternSegment := range patternSegments {
+			if patternSegment != "*" && patternSegment != segments[i] {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return pattern, true
+		}
+	}
+
+	return "", false
+}
+
+func fakeParent(collectionSegments []string) (string, string) {
+	if len(collectionSegments) < 3 {
+		return "", ""
+	}
+
+	parent := collectionSegments[:len(collectionSegments)-1]
+	return strings.Join(parent[:len(parent)-1], "/"), parent[len(parent)-1]
+}
+
+func fakeKeyResponse(key map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"id":         key["id"],
+		"created_by": key["created_by"],
+		"created_at": key["created_at"],
+	}
+}
+
+func validateFakeBody(kind string, body map[string]interface{}) map[string][]string {
+	validationErrors := map[string][]string{}
+	for _, field := range fakeRequiredFields[kind] {
+		if value, ok := body[field]; !ok || value == nil || value == "" {
+			validationErrors[field] = []string{fmt.Sprintf("The %s field is required.", field)}
+		}
+	}
+
+	if kind == "applications" {
+		applicationType, _ := body["type"].(string)
+		permissions, _ := body["permissions"].([]interface{})
+		if rules, ok := body["rules"].([]interface{}); ok {
+			for _, rule := range rules {
+				rulePermissions, _ := rule.(map[string]interface{})["permissions"].([]interface{})
+				permissions = append(permissions, rulePermissions...)
+			}
+		}
+		for _, permission := range permissions {
+			if valid, ok := defaultPermissionCatalog[applicationType]; ok && !containsString(valid, fmt.Sprint(permission)) {
+				validationErrors["permissions"] = append(validationErrors["permissions"], fmt.Sprintf("%v is not a valid permission for %s Applications.", permission, applicationType))
+			}
+		}
+	}
+
+	return validationErrors
+}
+
+func readFakeBody(w http.ResponseWriter, r *http.Request) (map[string]interface{}, bool) {
+	body := map[string]interface{}{}
+	if r.ContentLength == 0 {
+		return body, true
+	}
+
+	if err := json.NewDecoder(r.Body).Decode(&body); err != nil && !errors.Is(err, io.EOF) {
+		writeFakeProblem(w, http.StatusBadRequest, "Invalid JSON body", nil)
+		return nil, false
+	}
+
+	return body, true
+}
+
+func writeFakeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func writeFakeProblem(w http.ResponseWriter, status int, title string, validationErrors map[string][]string) {
+	problem := map[string]interface{}{
+		"title":  title,
+		"status": status,
+	}
+	if len(validationErrors) > 0 {
+		problem["errors"] = validationErrors
+	}
+
+	writeFakeJSON(w, status, problem)
+}
+
+func newFakeUUID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func newFakeKey() string {
+	return "key_test_" + strings.ReplaceAll(newFakeUUID(), "-", "")
+}
+
+func TestFakeBasisTheoryAPI_settlesProvisioningIntoRequestedState(t *testing.T) {
+	fake := newFakeBasisTheoryAPI()
+	t.Cleanup(fake.Close)
+	fake.setProvisioningOutcome("broken reactor", "failed")
+
+	active := fakeRequest(t, fake, http.MethodPost, "/reactors", `{"name":"reactor","code":"module.exports = async () => ({})"}`, http.StatusCreated)
+	failed := fakeRequest(t, fake, http.MethodPost, "/reactors", `{"name":"broken reactor","code":"module.exports = async () => ({})"}`, http.StatusCreated)
+
+	if active["state"] != "creating" || failed["state"] != "creating" {
+		t.Fatalf("expected reactors to start in the creating state, got %v and %v", active["state"], failed["state"])
+	}
+
+	for i := 0; i < fake.ProvisioningPolls; i++ {
+		fakeRequest(t, fake, http.MethodGet, "/reactors/"+active["id"].(string), "", http.StatusOK)
+		fakeRequest(t, fake, http.MethodGet, "/reactors/"+failed["id"].(string), "", http.StatusOK)
+	}
+
+	if actual := fakeRequest(t, fake, http.MethodGet, "/reactors/"+active["id"].(string), "", http.StatusOK); actual["state"] != "active" {
+		t.Fatalf("expected reactor to become active, got %v", actual["state"])
+	}
+
+	actual := fakeRequest(t, fake, http.MethodGet, "/reactors/"+failed["id"].(string), "", http.StatusOK)
+	if actual["state"] != "failed" {
+		t.Fatalf("expected reactor to fail, got %v", actual["state"])
+	}
+	if requested, _ := actual["requested"].(map[string]interface{}); requested["error_code"] != "provisioning_failed" {
+		t.Fatalf("expected failed reactor to report a requested error, got %v", actual["requested"])
+	}
+
+	updated := fakeRequest(t, fake, http.MethodPut, "/reactors/"+active["id"].(string), `{"name":"reactor","code":"module.exports = async () => ({ updated: true })"}`, http.StatusOK)
+	if updated["state"] != "updating" {
+		t.Fatalf("expected updated reactor to be updating, got %v", updated["state"])
+	}
+}
+
+func TestFakeBasisTheoryAPI_servesCrudWithValidationAndNotFound(t *testing.T) {
+	fake := newFakeBasisTheoryAPI()
+	t.Cleanup(fake.Close)
+
+	problem := fakeRequest(t, fake, http.MethodPost, "/webhooks", `{"name":"webhook"}`, http.StatusBadRequest)
+	if validationErrors, _ := problem["errors"].(map[string]interface{}); validationErrors["url"] == nil || validationErrors["events"] == nil {
+		t.Fatalf("expected missing fields to be reported, got %v", problem)
+	}
+
+	application := fakeRequest(t, fake, http.MethodPost, "/applications", `{"name":"app","type":"private","create_key":true}`, http.StatusCreated)
+	if !regexp.MustCompile(testUuidRegex).MatchString(application["id"].(string)) {
+		t.Fatalf("expected a UUID id, got %v", application["id"])
+	}
+	if keys, _ := application["keys"].([]interface{}); len(keys) != 1 || keys[0].(map[string]interface{})["key"] == nil {
+		t.Fatalf("expected the created key to be returned once, got %v", application["keys"])
+	}
+	if _, ok := application["create_key"]; ok {
+		t.Fatalf("expected create_key not to be returned")
+	}
+
+	applicationID := application["id"].(string)
+	key := fakeRequest(t, fake, http.MethodPost, "/applications/"+applicationID+"/keys", "", http.StatusCreated)
+	if read := fakeRequest(t, fake, http.MethodGet, "/applications/"+applicationID+"/keys/"+key["id"].(string), "", http.StatusOK); read["key"] != nil {
+		t.Fatalf("expected key value to be write-once, got %v", read["key"])
+	}
+
+	fakeRequest(t, fake, http.MethodPost, "/applications", `{"name":"other","type":"public"}`, http.StatusCreated)
+	list := fakeRequest(t, fake, http.MethodGet, "/applications?type=private", "", http.StatusOK)
+	if data, _ := list["data"].([]interface{}); len(data) != 1 {
+		t.Fatalf("expected type filter to match one application, got %v", list["data"])
+	}
+
+	fakeRequest(t, fake, http.MethodDelete, "/applications/"+applicationID, "", http.StatusNoContent)
+	fakeRequest(t, fake, http.MethodGet, "/applications/"+applicationID, "", http.StatusNotFound)
+	fakeRequest(t, fake, http.MethodGet, "/applications/"+applicationID+"/keys/"+key["id"].(string), "", http.StatusNotFound)
+}
+
+func TestFakeBasisTheoryAPI_replacesApplePayDomains(t *testing.T) {
+	fake := newFakeBasisTheoryAPI()
+	t.Cleanup(fake.Close)
+
+	fakeRequest(t, fake, http.MethodPut, "/apple-pay/domain-registration", `{"domains":["b.example.com","a.example.com"]}`, http.StatusOK)
+	fakeRequest(t, fake, http.MethodPut, "/apple-pay/domain-registration", `{"domains":["a.example.com"]}`, http.StatusOK)
+	response := fakeRequest(t, fake, http.MethodGet, "/apple-pay/domain-registration", "", http.StatusOK)
+
+	var domains []string
+	for _, domain := range response["domains"].([]interface{}) {
+		domains = append(domains, domain.(map[string]interface{})["domain"].(string))
+	}
+	sort.Strings(domains)
+
+	if len(domains) != 1 || domains[0] != "a.example.com" {
+		t.Fatalf("expected only a.example.com to be registered, got %v", domains)
+	}
+}
+
+func fakeRequest(t *testing.T, fake *fakeBasisTheoryAPI, method string, path string, body string, expectedStatus int) map[string]interface{} {
+	t.Helper()
+
+	req, err := http.NewRequest(method, fake.URL()+path, strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	req.Header.Set("BT-API-KEY", fakeAPIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := fake.server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != expectedStatus {
+		t.Fatalf("%s %s: expected %d, got %d", method, path, expectedStatus, resp.StatusCode)
+	}
+
+	decoded := map[string]interface{}{}
+	if resp.StatusCode != http.StatusNoContent {
+		if err := json.NewDecoder(resp.Body).Decode(&decoded); err != nil {
+			t.Fatalf("%s %s: invalid JSON response: %s", method, path, err)
+		}
+	}
+
+	return decoded
+}
@@ -0,0 +1,290 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// permissionCatalog lists the permissions each Application type can be
+// granted, keyed by Application type.
+type permissionCatalog map[string][]string
+
+// defaultPermissionCatalog lets plans be validated without calling the API.
+// It can lag behind the API; the provider's refresh_permission_catalog option
+// replaces it with the API's own list.
+var defaultPermissionCatalog = permissionCatalog{
+	"public": {
+		"token:create",
+		"token:update",
+		"token-intent:create",
+	},
+	"private": {
+		"token:create",
+		"token:read",
+		"token:update",
+		"token:delete",
+		"token:search",
+		"token:use",
+		"token:reveal",
+		"token-intent:create",
+		"token-intent:read",
+		"token-intent:delete",
+	},
+	"management": {
+		"application:create",
+		"application:read",
+		"application:update",
+		"application:delete",
+		"proxy:create",
+		"proxy:read",
+		"proxy:update",
+		"proxy:delete",
+		"reactor:create",
+		"reactor:read",
+		"reactor:update",
+		"reactor:delete",
+		"webhook:create",
+		"webhook:read",
+		"webhook:update",
+		"webhook:delete",
+		"tenant:read",
+		"tenant:update",
+		"tenant:delete",
+		"tenant:member:read",
+		"tenant:member:update",
+		"tenant:member:delete",
+		"tenant:invitation:create",
+		"tenant:invitation:read",
+		"tenant:invitation:delete",
+		"log:read",
+		"report:read",
+	},
+}
+
+// runtimePermissionApplicationType is the Application type whose permissions
+// Reactor and Proxy runtimes can be granted.
+const runtimePermissionApplicationType = "private"
+
+// maxPermissionSuggestionDistance bounds how different a known permission can
+// be from an unknown one to still be suggested.
+const maxPermissionSuggestionDistance = 3
+
+func fetchPermissionCatalog(ctx context.Context, client *basistheoryClient.Client) (permissionCatalog, error) {
+	permissions, err := client.Permissions.List(ctx, &basistheory.PermissionsListRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return permissionCatalogFromPermissions(permissions), nil
+}
+
+func permissionCatalogFromPermissions(permissions []*basistheory.Permission) permissionCatalog {
+	catalog := permissionCatalog{}
+	for _, permission := range permissions {
+		for _, applicationType := range permission.ApplicationTypes {
+			catalog[applicationType] = append(catalog[applicationType], getStringValue(permission.Type))
+		}
+	}
+
+	return catalog
+}
+
+// permissionCatalogFromMeta returns the catalog refreshed from the API when the
+// provider was configured to do so, or the embedded one, and whether it was
+// refreshed.
+func permissionCatalogFromMeta(meta interface{}) (permissionCatalog, bool) {
+	if metaMap, ok := meta.(map[string]interface{}); ok {
+		if catalog, ok := metaMap["permission_catalog"].(permissionCatalog); ok && len(catalog) > 0 {
+			return catalog, true
+		}
+	}
+
+	return defaultPermissionCatalog, false
+}
+
+// validatePermissions checks permissions against the ones applicationType can
+// be granted. Errors are cty.PathErrors on path, indexed by position when
+// indexed is true. Likely typos are only errors when the catalog is refreshed,
+// since the embedded one can lag behind the API.
+func (catalog permissionCatalog) validatePermissions(applicationType string, permissions []interface{}, path cty.Path, indexed bool, refreshed bool) []error {
+	var errs []error
+	for i, permission := range permissions {
+		permissionPath := path
+		if indexed {
+			permissionPath = path.IndexInt(i)
+		}
+
+		if err := catalog.validatePermission(applicationType, permission.(string), refreshed); err != nil {
+			errs = append(errs, permissionPath.NewError(err))
+		}
+	}
+
+	return errs
+}
+
+func (catalog permissionCatalog) validatePermission(applicationType string, permission string, refreshed bool) error {
+	valid, ok := catalog[applicationType]
+	if !ok || containsString(valid, permission) {
+		return nil
+	}
+
+	if otherTypes := catalog.applicationTypesGranting(permission); len(otherTypes) > 0 {
+		return fmt.Errorf("%q is not a valid permission for %s Applications, only for %s Applications", permission, applicationType, joinWithAnd(otherTypes))
+	}
+
+	if suggestion := closestPermission(permission, valid); suggestion != "" && refreshed {
+		return fmt.Errorf("%q is not a known permission, did you mean %q?", permission, suggestion)
+	}
+
+	// May be a permission the catalog does not list yet, which
+	// warnUnknownPermissions reports after the apply
+	return nil
+}
+
+// applicationTypesGranting returns the sorted Application types whose catalog
+// lists permission.
+func (catalog permissionCatalog) applicationTypesGranting(permission string) []string {
+	var applicationTypes []string
+	for applicationType, permissions := range catalog {
+		if containsString(permissions, permission) {
+			applicationTypes = append(applicationTypes, applicationType)
+		}
+	}
+	sort.Strings(applicationTypes)
+
+	return applicationTypes
+}
+
+func joinWithAnd(values []string) string {
+	if len(values) < 2 {
+		return strings.Join(values, "")
+	}
+
+	return strings.Join(values[:len(values)-1], ", ") + " and " + values[len(values)-1]
+}
+
+// unknownPermissions returns the permissions that are in no Application
+// type's catalog, including likely typos unless the catalog is refreshed.
+func (catalog permissionCatalog) unknownPermissions(applicationType string, permissions []interface{}, refreshed bool) []string {
+	valid, ok := catalog[applicationType]
+	if !ok {
+		return nil
+	}
+
+	var unknown []string
+	for _, permission := range permissions {
+		permission := permission.(string)
+		if containsString(valid, permission) || containsString(unknown, permission) {
+			continue
+		}
+		if catalog.validatePermission(applicationType, permission, refreshed) == nil {
+			unknown = append(unknown, permission)
+		}
+	}
+	sort.Strings(unknown)
+
+	return unknown
+}
+
+// warnUnknownPermissions wraps a create or update function to warn about the
+// permissions returned by permissionsFromData that the catalog does not know.
+// They are not rejected at plan time, since the catalog can lag behind the API.
+func warnUnknownPermissions(apply func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics, permissionsFromData func(*schema.ResourceData) (string, []interface{})) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	return func(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		diags := apply(ctx, data, meta)
+		if diags.HasError() {
+			return diags
+		}
+
+		applicationType, permissions := permissionsFromData(data)
+		catalog, refreshed := permissionCatalogFromMeta(meta)
+		unknown := catalog.unknownPermissions(applicationType, permissions, refreshed)
+		if len(unknown) == 0 {
+			return diags
+		}
+
+		quoted := make([]string, 0, len(unknown))
+		for _, permission := range unknown {
+			if suggestion := closestPermission(permission, catalog[applicationType]); suggestion != "" {
+				quoted = append(quoted, fmt.Sprintf("%q (did you mean %q?)", permission, suggestion))
+			} else {
+				quoted = append(quoted, fmt.Sprintf("%q", permission))
+			}
+		}
+
+		return append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Unknown permissions",
+			Detail:   fmt.Sprintf("%s are not known permissions for %s Applications and were sent to the API as configured. If they are new permissions, set refresh_permission_catalog to validate them against the API's catalog.", strings.Join(quoted, ", "), applicationType),
+		})
+	}
+}
+
+func closestPermission(permission string, candidates []string) string {
+	closest := ""
+	closestDistance := maxPermissionSuggestionDistance + 1
+
+	sorted := append([]string(nil), candidates...)
+	sort.Strings(sorted)
+	for _, candidate := range sorted {
+		if distance := levenshteinDistance(permission, candidate); distance < closestDistance {
+			closest, closestDistance = candidate, distance
+		}
+	}
+
+	return closest
+}
+
+func levenshteinDistance(a string, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			substitution := previous[j-1]
+			if a[i-1] != b[j-1] {
+				substitution++
+			}
+			current[j] = minInt(substitution, minInt(previous[j]+1, current[j-1]+1))
+		}
+		previous = current
+	}
+
+	return previous[len(b)]
+}
+
+func minInt(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
+
+// joinPathErrors reports every error in one, since CustomizeDiff can only
+// return a single error, and points Terraform at the first offending path.
+func joinPathErrors(errs []error) error {
+	if len(errs) == 0 {
+		return nil
+	}
+
+	messages := make([]string, 0, len(errs))
+	for _, err := range errs {
+		messages = append(messages, err.Error())
+	}
+
+	return errorAttributePath(errs[0]).NewError(errors.New(strings.Join(messages, "\n")))
+}
@@ -0,0 +1,224 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestPermissionCatalog_validatePermission(t *testing.T) {
+	for name, testCase := range map[string]struct {
+		applicationType string
+		permission      string
+		refreshed       bool
+		expectedError   string
+	}{
+		"valid":                {"private", "token:read", false, ""},
+		"typo":                 {"private", "token:raed", false, ""},
+		"refreshed typo":       {"private", "token:raed", true, `"token:raed" is not a known permission, did you mean "token:read"?`},
+		"other type":           {"public", "token:read", false, `"token:read" is not a valid permission for public Applications, only for private Applications`},
+		"unknown":              {"management", "something:else:entirely", true, ""},
+		"unknown app type":     {"future", "anything", true, ""},
+		"missing from catalog": {"management", "tenant:invitation:update", false, ""},
+		"refreshed management": {"management", "proxy:craete", true, `did you mean "proxy:create"?`},
+		"runtime permission":   {runtimePermissionApplicationType, "token:reveal", false, ""},
+	} {
+		err := defaultPermissionCatalog.validatePermission(testCase.applicationType, testCase.permission, testCase.refreshed)
+
+		if testCase.expectedError == "" {
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %s", name, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), testCase.expectedError) {
+			t.Fatalf("%s: expected error %q, got %v", name, testCase.expectedError, err)
+		}
+	}
+}
+
+func TestPermissionCatalog_validatePermission_listsEveryOtherTypeInOrder(t *testing.T) {
+	catalog := permissionCatalog{
+		"public":     {"token:create"},
+		"private":    {"token:read"},
+		"management": {"token:read"},
+		"future":     {"token:read"},
+	}
+
+	for i := 0; i < 10; i++ {
+		err := catalog.validatePermission("public", "token:read", false)
+		if expected := `"token:read" is not a valid permission for public Applications, only for future, management and private Applications`; err == nil || err.Error() != expected {
+			t.Fatalf("expected error %q, got %v", expected, err)
+		}
+	}
+}
+
+func TestPermissionCatalog_unknownPermissions(t *testing.T) {
+	permissions := []interface{}{"token:read", "token:raed", "token:brand-new", "tenant:read", "token:brand-new", "another:new"}
+
+	if actual, expected := defaultPermissionCatalog.unknownPermissions("private", permissions, false), []string{"another:new", "token:brand-new", "token:raed"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+	if actual, expected := defaultPermissionCatalog.unknownPermissions("private", permissions, true), []string{"another:new", "token:brand-new"}; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected typos to be left to the plan with a refreshed catalog, got %v", actual)
+	}
+	if actual := defaultPermissionCatalog.unknownPermissions("future", []interface{}{"anything"}, false); actual != nil {
+		t.Fatalf("expected unknown Application types to be skipped, got %v", actual)
+	}
+}
+
+func TestWarnUnknownPermissions(t *testing.T) {
+	apply := warnUnknownPermissions(func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+		return nil
+	}, applicationPermissionsFromData)
+
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryApplication().Schema, map[string]interface{}{
+		"name":        "Terraform application",
+		"type":        "private",
+		"permissions": []interface{}{"token:read", "token:brand-new", "token:raed"},
+		"rule": []interface{}{map[string]interface{}{
+			"description": "TEST_RULE",
+			"priority":    1,
+			"container":   "/",
+			"transform":   "reveal",
+			"permissions": []interface{}{"another:new"},
+		}},
+	})
+
+	diags := apply(context.Background(), data, nil)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("expected a single warning, got %v", diags)
+	}
+	if expected := `"another:new", "token:brand-new", "token:raed" (did you mean "token:read"?) are not known permissions for private Applications`; !strings.Contains(diags[0].Detail, expected) {
+		t.Fatalf("expected %q in the warning, got %s", expected, diags[0].Detail)
+	}
+
+	refreshed := map[string]interface{}{"permission_catalog": permissionCatalog{"private": {"token:read", "token:brand-new", "token:raed", "another:new"}}}
+	if diags := apply(context.Background(), data, refreshed); len(diags) != 0 {
+		t.Fatalf("expected no warning with a refreshed catalog, got %v", diags)
+	}
+}
+
+func TestPermissionCatalogFromPermissions(t *testing.T) {
+	read, create := "token:read", "token:create"
+
+	actual := permissionCatalogFromPermissions([]*basistheory.Permission{
+		{Type: &read, ApplicationTypes: []string{"private"}},
+		{Type: &create, ApplicationTypes: []string{"public", "private"}},
+	})
+
+	if expected := (permissionCatalog{"private": {read, create}, "public": {create}}); !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %v, got %v", expected, actual)
+	}
+}
+
+func TestPermissionCatalogFromMeta_fallsBackToDefault(t *testing.T) {
+	refreshed := permissionCatalog{"private": {"token:read"}}
+
+	if actual, isRefreshed := permissionCatalogFromMeta(map[string]interface{}{"permission_catalog": refreshed}); !reflect.DeepEqual(actual, refreshed) || !isRefreshed {
+		t.Fatalf("expected the refreshed catalog, got %v", actual)
+	}
+	for _, meta := range []interface{}{map[string]interface{}{}, map[string]interface{}{"permission_catalog": permissionCatalog(nil)}, nil} {
+		if actual, isRefreshed := permissionCatalogFromMeta(meta); !reflect.DeepEqual(actual, defaultPermissionCatalog) || isRefreshed {
+			t.Fatalf("expected the default catalog, got %v", actual)
+		}
+	}
+}
+
+func TestPermissionsCustomizeDiff_reportsInvalidPermissionsWithAttributePath(t *testing.T) {
+	refreshedMeta := map[string]interface{}{"permission_catalog": defaultPermissionCatalog}
+
+	for name, testCase := range map[string]struct {
+		resource     *schema.Resource
+		config       map[string]interface{}
+		expectedPath cty.Path
+	}{
+		"Application": {
+			resource: resourceBasisTheoryApplication(),
+			config: map[string]interface{}{
+				"name":        "Terraform application",
+				"type":        "public",
+				"permissions": []interface{}{"token:create", "token:crate"},
+			},
+			expectedPath: cty.GetAttrPath("permissions"),
+		},
+		"Application rule": {
+			resource: resourceBasisTheoryApplication(),
+			config: map[string]interface{}{
+				"name": "Terraform application",
+				"type": "private",
+				"rule": []interface{}{map[string]interface{}{
+					"description": "TEST_RULE",
+					"priority":    1,
+					"container":   "/",
+					"transform":   "reveal",
+					"permissions": []interface{}{"token:raed"},
+				}},
+			},
+			expectedPath: cty.GetAttrPath("rule"),
+		},
+		"Reactor": {
+			resource: resourceBasisTheoryReactor(),
+			config: map[string]interface{}{
+				"name":    "Terraform reactor",
+				"code":    "module.exports = async function (context) { return context; };",
+				"runtime": []interface{}{map[string]interface{}{"permissions": []interface{}{"token:read", "token:raed"}}},
+			},
+			expectedPath: cty.GetAttrPath("runtime").IndexInt(0).GetAttr("permissions").IndexInt(1),
+		},
+		"Proxy": {
+			resource: resourceBasisTheoryProxy(),
+			config: map[string]interface{}{
+				"name":            "Terraform proxy",
+				"destination_url": "https://httpbin.org/post",
+				"request_transforms": []interface{}{map[string]interface{}{
+					"type": "code",
+					"code": "module.exports = async function (context) { return context; };",
+					"options": []interface{}{map[string]interface{}{
+						"runtime": []interface{}{map[string]interface{}{"permissions": []interface{}{"token:raed"}}},
+					}},
+				}},
+			},
+			expectedPath: cty.GetAttrPath("request_transforms").IndexInt(0).GetAttr("options").IndexInt(0).GetAttr("runtime").IndexInt(0).GetAttr("permissions").IndexInt(0),
+		},
+	} {
+		if _, err := testCase.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(testCase.config), nil); err != nil {
+			t.Fatalf("%s: expected likely typos to be left to the API with the embedded catalog, got %s", name, err)
+		}
+
+		_, err := testCase.resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(testCase.config), refreshedMeta)
+
+		var pathError cty.PathError
+		if !errors.As(err, &pathError) {
+			t.Fatalf("%s: expected a path error, got %v", name, err)
+		}
+		if !pathError.Path.Equals(testCase.expectedPath) {
+			t.Fatalf("%s: expected path %#v, got %#v", name, testCase.expectedPath, pathError.Path)
+		}
+		if !strings.Contains(err.Error(), "did you mean") {
+			t.Fatalf("%s: expected a suggestion, got %s", name, err)
+		}
+	}
+}
+
+func TestPermissionsCustomizeDiff_usesRefreshedCatalog(t *testing.T) {
+	meta := map[string]interface{}{"permission_catalog": permissionCatalog{"private": {"token:read", "token:brand-new"}}}
+
+	_, err := resourceBasisTheoryApplication().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":        "Terraform application",
+		"type":        "private",
+		"permissions": []interface{}{"token:brand-new"},
+	}), meta)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
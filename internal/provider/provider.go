@@ -10,6 +10,7 @@ import (
 	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/meta"
 )
 
@@ -18,7 +19,13 @@ func init() {
 }
 
 func BasisTheoryProvider(client *basistheory.Client) func() *schema.Provider {
-	const BasisTheoryClientDefaultTimeout = 15
+	const (
+		BasisTheoryClientDefaultTimeout       = 15
+		BasisTheoryClientDefaultMaxRetries    = 3
+		BasisTheoryClientDefaultRetryMinWait  = 1
+		BasisTheoryClientDefaultRetryMaxWait  = 30
+		BasisTheoryDefaultProvisioningTimeout = "10m"
+	)
 
 	return func() *schema.Provider {
 		provider := &schema.Provider{
@@ -41,9 +48,54 @@ func BasisTheoryProvider(client *basistheory.Client) func() *schema.Provider {
 					Description: "Timeout (in seconds) for the BasisTheory client. Defaults to 15 seconds. Can be set through BASISTHEORY_CLIENT_TIMEOUT env var",
 					DefaultFunc: schema.EnvDefaultFunc("BASISTHEORY_CLIENT_TIMEOUT", BasisTheoryClientDefaultTimeout),
 				},
+				"max_retries": {
+					Optional:     true,
+					Type:         schema.TypeInt,
+					Description:  "Maximum number of times a request is retried after a 429, 502, 503 or 504 response. Set to 0 to disable retries. Defaults to 3. Can be set through BASISTHEORY_MAX_RETRIES env var",
+					DefaultFunc:  schema.EnvDefaultFunc("BASISTHEORY_MAX_RETRIES", BasisTheoryClientDefaultMaxRetries),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"retry_min_wait": {
+					Optional:     true,
+					Type:         schema.TypeInt,
+					Description:  "Minimum time (in seconds) to wait before retrying a request. The wait doubles on every attempt unless the API returns a Retry-After header. Defaults to 1 second. Can be set through BASISTHEORY_RETRY_MIN_WAIT env var",
+					DefaultFunc:  schema.EnvDefaultFunc("BASISTHEORY_RETRY_MIN_WAIT", BasisTheoryClientDefaultRetryMinWait),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"retry_max_wait": {
+					Optional:     true,
+					Type:         schema.TypeInt,
+					Description:  "Maximum time (in seconds) to wait before retrying a request, including waits requested through a Retry-After header. Defaults to 30 seconds. Can be set through BASISTHEORY_RETRY_MAX_WAIT env var",
+					DefaultFunc:  schema.EnvDefaultFunc("BASISTHEORY_RETRY_MAX_WAIT", BasisTheoryClientDefaultRetryMaxWait),
+					ValidateFunc: validation.IntAtLeast(0),
+				},
+				"provisioning_timeout": {
+					Optional:     true,
+					Type:         schema.TypeString,
+					Description:  "Default time to wait for Proxies and Reactors to reach a final state when the resource has no `timeouts` block, as a duration string (e.g. `10m`, `1h`). Defaults to 10m. Can be set through BASISTHEORY_PROVISIONING_TIMEOUT env var",
+					DefaultFunc:  schema.EnvDefaultFunc("BASISTHEORY_PROVISIONING_TIMEOUT", BasisTheoryDefaultProvisioningTimeout),
+					ValidateFunc: validateDuration,
+				},
+				"refresh_permission_catalog": {
+					Optional:    true,
+					Type:        schema.TypeBool,
+					Description: "Whether to replace the permission catalog embedded in the provider, used to validate permissions at plan time, with the permissions listed by the API. Permissions missing from the catalog are reported as warnings after the apply, and likely typos of known permissions only fail the plan once the catalog is refreshed. If the API can't be reached, a warning is reported and the embedded catalog is used. Defaults to false. Can be set through BASISTHEORY_REFRESH_PERMISSION_CATALOG env var",
+					DefaultFunc: schema.EnvDefaultFunc("BASISTHEORY_REFRESH_PERMISSION_CATALOG", false),
+				},
+			},
+			DataSourcesMap: map[string]*schema.Resource{
+				"basistheory_application":  dataSourceBasisTheoryApplication(),
+				"basistheory_applications": dataSourceBasisTheoryApplications(),
+				"basistheory_permissions":  dataSourceBasisTheoryPermissions(),
+				"basistheory_proxies":      dataSourceBasisTheoryProxies(),
+				"basistheory_proxy":        dataSourceBasisTheoryProxy(),
+				"basistheory_reactor":      dataSourceBasisTheoryReactor(),
+				"basistheory_reactors":     dataSourceBasisTheoryReactors(),
+				"basistheory_tokens":       dataSourceBasisTheoryTokens(),
 			},
 			ResourcesMap: map[string]*schema.Resource{
 				"basistheory_applepay_domain":                  resourceApplePayDomain(),
+				"basistheory_applepay_domain_registration":     resourceBasisTheoryApplePayDomainRegistration(),
 				"basistheory_apple_pay_merchant_registration":  resourceBasisTheoryApplePayMerchantRegistration(),
 				"basistheory_apple_pay_merchant_certificates":  resourceBasisTheoryApplePayMerchantCertificates(),
 				"basistheory_application":                      resourceBasisTheoryApplication(),
@@ -52,6 +104,7 @@ func BasisTheoryProvider(client *basistheory.Client) func() *schema.Provider {
 				"basistheory_google_pay_merchant_certificates": resourceBasisTheoryGooglePayMerchantCertificates(),
 				"basistheory_proxy":                            resourceBasisTheoryProxy(),
 				"basistheory_reactor":                          resourceBasisTheoryReactor(),
+				"basistheory_token":                            resourceBasisTheoryToken(),
 				"basistheory_webhook":                          resourceBasisTheoryWebhook(),
 			},
 		}
@@ -63,37 +116,70 @@ func BasisTheoryProvider(client *basistheory.Client) func() *schema.Provider {
 
 func configure(client *basistheory.Client, provider *schema.Provider) func(context.Context, *schema.ResourceData) (interface{}, diag.Diagnostics) {
 	return func(ctx context.Context, data *schema.ResourceData) (interface{}, diag.Diagnostics) {
+		if data.Get("retry_min_wait").(int) > data.Get("retry_max_wait").(int) {
+			return nil, diag.Errorf("retry_min_wait (%d) must be less than or equal to retry_max_wait (%d)", data.Get("retry_min_wait"), data.Get("retry_max_wait"))
+		}
+
+		provisioningTimeout, err := time.ParseDuration(data.Get("provisioning_timeout").(string))
+		if err != nil {
+			return nil, diag.Errorf("invalid provisioning_timeout: %s", err)
+		}
+
 		if client != nil {
 			return map[string]interface{}{
-				"client":  client,
-				"api_key": data.Get("api_key"),
-				"api_url": data.Get("api_url"),
+				"client":               client,
+				"provisioning_timeout": provisioningTimeout,
 			}, nil
 		}
 
 		userAgent := fmt.Sprintf("HashiCorp Terraform/%s Basis Theory Terraform Plugin SDK/%s", provider.TerraformVersion, meta.SDKVersionString())
 
+		apiClient := newClient(data, userAgent, newHTTPClient(data))
+
 		var diags diag.Diagnostics
 
+		var catalog permissionCatalog
+		if data.Get("refresh_permission_catalog").(bool) {
+			if refreshed, err := fetchPermissionCatalog(ctx, apiClient); err != nil {
+				diags = append(diags, diag.Diagnostic{
+					Severity: diag.Warning,
+					Summary:  "Unable to refresh the permission catalog",
+					Detail:   fmt.Sprintf("Permissions are validated against the catalog embedded in the provider: %s", err),
+				})
+			} else {
+				catalog = refreshed
+			}
+		}
+
 		return map[string]interface{}{
-			"client":  newClient(data, userAgent),
-			"api_key": data.Get("api_key"),
-			"api_url": data.Get("api_url"),
+			"client":               apiClient,
+			"provisioning_timeout": provisioningTimeout,
+			"permission_catalog":   catalog,
 		}, diags
 	}
 }
 
-func newClient(data *schema.ResourceData, userAgent string) *basistheory.Client {
+func newHTTPClient(data *schema.ResourceData) *retryHTTPClient {
+	return newRetryHTTPClient(
+		&http.Client{
+			Timeout: time.Duration(data.Get("client_timeout").(int)) * time.Second,
+		},
+		data.Get("max_retries").(int),
+		time.Duration(data.Get("retry_min_wait").(int))*time.Second,
+		time.Duration(data.Get("retry_max_wait").(int))*time.Second,
+	)
+}
+
+func newClient(data *schema.ResourceData, userAgent string, httpClient *retryHTTPClient) *basistheory.Client {
 	return basistheory.NewClient(
 		option.WithAPIKey(data.Get("api_key").(string)),
 		option.WithBaseURL(data.Get("api_url").(string)),
 		option.WithHTTPHeader(map[string][]string{
 			"User-Agent": {userAgent},
 		}),
-		option.WithHTTPClient(
-			&http.Client{
-				Timeout: time.Duration(data.Get("client_timeout").(int)) * time.Second,
-			},
-		),
+		option.WithHTTPClient(httpClient),
+		// Retries are owned by retryHTTPClient, which only repeats safe calls;
+		// the SDK's own retrier would also replay non-idempotent writes.
+		option.WithMaxAttempts(1),
 	)
 }
@@ -18,7 +18,7 @@ import (
 func getAccProvider() *schema.Provider {
 	missingEnvVars := getMissingEnvVars()
 
-	if missingEnvVars != nil {
+	if missingEnvVars != nil && testFakeAPI == nil {
 		fmt.Printf("%v must be set before running acceptance tests.", strings.Join(missingEnvVars, ", "))
 		os.Exit(1)
 	}
@@ -30,8 +30,8 @@ func getAccProvider() *schema.Provider {
 
 func newTestClient(userAgent string) *basistheory.Client {
 	return basistheory.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
+		option.WithAPIKey(testAPIKey()),
+		option.WithBaseURL(testAPIURL()),
 		option.WithHTTPHeader(map[string][]string{
 			"User-Agent": {userAgent},
 		}),
@@ -43,6 +43,33 @@ func newTestClient(userAgent string) *basistheory.Client {
 	)
 }
 
+// newTestAPIClient returns a client for checks that call the API directly,
+// such as destroy checks, targeting the same API as the provider under test.
+func newTestAPIClient() *basistheory.Client {
+	return basistheory.NewClient(
+		option.WithAPIKey(testAPIKey()),
+		option.WithBaseURL(testAPIURL()),
+	)
+}
+
+// testAPIKey and testAPIURL point the tests at the fake when it runs, and at
+// the API configured through the environment otherwise.
+func testAPIKey() string {
+	if testFakeAPI != nil {
+		return fakeAPIKey
+	}
+
+	return os.Getenv("BASISTHEORY_API_KEY")
+}
+
+func testAPIURL() string {
+	if testFakeAPI != nil {
+		return testFakeAPI.URL()
+	}
+
+	return os.Getenv("BASISTHEORY_API_URL")
+}
+
 func getProviderFactories() map[string]func() (*schema.Provider, error) {
 	return map[string]func() (*schema.Provider, error){
 		"basistheory": func() (*schema.Provider, error) {
@@ -58,6 +85,10 @@ func TestProvider(t *testing.T) {
 }
 
 func preCheck(t *testing.T) {
+	if testFakeAPI != nil {
+		return
+	}
+
 	missingEnvVars := getMissingEnvVars()
 
 	if missingEnvVars != nil {
@@ -0,0 +1,254 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+const (
+	provisioningDefaultTimeout    = 10 * time.Minute
+	provisioningPollMinInterval   = 1 * time.Second
+	provisioningPollMaxInterval   = 30 * time.Second
+	provisioningPollBackoffFactor = 2
+	provisioningUnsetTimeout      = time.Duration(0)
+
+	waitForActiveKey = "wait_for_active"
+	acceptStatesKey  = "accept_states"
+)
+
+// provisioningLocalKeys are only read by the provider, so changing them alone
+// does not require an API call.
+var provisioningLocalKeys = []string{deletionProtectionKey, waitForActiveKey, acceptStatesKey}
+
+// provisioningResourceTimeouts declares create, update and delete timeouts for
+// resources that wait on provisioning. The zero defaults mark a timeout as not
+// configured so provisioningTimeout can fall back to the provider default.
+func provisioningResourceTimeouts() *schema.ResourceTimeout {
+	return &schema.ResourceTimeout{
+		Create: schema.DefaultTimeout(provisioningUnsetTimeout),
+		Update: schema.DefaultTimeout(provisioningUnsetTimeout),
+		Delete: schema.DefaultTimeout(provisioningUnsetTimeout),
+	}
+}
+
+// withProvisioningTimeout bounds ctx by the timeout configured for the given
+// operation. Resources using it must register their functions as
+// *WithoutTimeout so the SDK does not apply the zero placeholder itself.
+func withProvisioningTimeout(ctx context.Context, data *schema.ResourceData, meta interface{}, key string) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(ctx, provisioningTimeout(data, meta, key))
+}
+
+func provisioningTimeout(data *schema.ResourceData, meta interface{}, key string) time.Duration {
+	if timeout := data.Timeout(key); timeout != provisioningUnsetTimeout {
+		return timeout
+	}
+
+	if timeout, ok := meta.(map[string]interface{})["provisioning_timeout"].(time.Duration); ok && timeout > 0 {
+		return timeout
+	}
+
+	return provisioningDefaultTimeout
+}
+
+// provisioningPollInterval backs off exponentially between polls and adds
+// jitter so parallel applies do not poll the API in lockstep.
+func provisioningPollInterval(attempt int) time.Duration {
+	interval := provisioningPollMinInterval
+	for i := 0; i < attempt && interval < provisioningPollMaxInterval; i++ {
+		interval *= provisioningPollBackoffFactor
+	}
+	if interval > provisioningPollMaxInterval {
+		interval = provisioningPollMaxInterval
+	}
+
+	half := interval / 2
+	return half + time.Duration(rand.Int63n(int64(half)+1))
+}
+
+// provisioningRequestedSchema describes the outcome of the last provisioning
+// request of a Proxy or Reactor, as reported by the API.
+func provisioningRequestedSchema(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Outcome of the last provisioning request for the %s", resourceName),
+		Type:        schema.TypeList,
+		Computed:    true,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"state": {
+					Description: "State of the provisioning request",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"error_code": {
+					Description: "Error code reported when provisioning did not succeed",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"error_message": {
+					Description: "Error message reported when provisioning did not succeed",
+					Type:        schema.TypeString,
+					Computed:    true,
+				},
+				"vulnerabilities": {
+					Description: "Vulnerabilities found in the dependencies of the code",
+					Type:        schema.TypeList,
+					Computed:    true,
+					Elem: &schema.Resource{
+						Schema: map[string]*schema.Schema{
+							"name": {
+								Description: "Name of the vulnerable package",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"version": {
+								Description: "Version of the vulnerable package",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"severity": {
+								Description: "Severity of the vulnerability",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"id": {
+								Description: "Identifier of the vulnerability, such as a CVE",
+								Type:        schema.TypeString,
+								Computed:    true,
+							},
+							"dependency_path": {
+								Description: "Chain of dependencies through which the vulnerable package is included",
+								Type:        schema.TypeList,
+								Computed:    true,
+								Elem: &schema.Schema{
+									Type: schema.TypeString,
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// flattenProvisioningRequested flattens the requested fields shared by Proxies
+// and Reactors into a single block.
+func flattenProvisioningRequested(state *string, errorCode *string, errorMessage *string, errorDetails map[string]interface{}) []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"state":           getStringValue(state),
+			"error_code":      getStringValue(errorCode),
+			"error_message":   getStringValue(errorMessage),
+			"vulnerabilities": flattenRequestedVulnerabilities(errorDetails),
+		},
+	}
+}
+
+// flattenRequestedVulnerabilities reads the vulnerabilities out of the
+// untyped error details. Entries that are not objects are skipped.
+func flattenRequestedVulnerabilities(errorDetails map[string]interface{}) []interface{} {
+	vulnerabilities, _ := errorDetails["vulnerabilities"].([]interface{})
+
+	result := []interface{}{}
+	for _, vulnerability := range vulnerabilities {
+		vulnerabilityMap, ok := vulnerability.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		var dependencyPath []interface{}
+		if path, ok := vulnerabilityMap["dependency_path"].([]interface{}); ok {
+			for _, dependency := range path {
+				dependencyPath = append(dependencyPath, fmt.Sprint(dependency))
+			}
+		}
+
+		result = append(result, map[string]interface{}{
+			"name":            requestedDetailString(vulnerabilityMap["name"]),
+			"version":         requestedDetailString(vulnerabilityMap["version"]),
+			"severity":        requestedDetailString(vulnerabilityMap["severity"]),
+			"id":              requestedDetailString(vulnerabilityMap["id"]),
+			"dependency_path": dependencyPath,
+		})
+	}
+
+	return result
+}
+
+func requestedDetailString(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+
+	return fmt.Sprint(value)
+}
+
+func waitForActiveSchema(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Whether create and update wait for the %s to finish provisioning. When `false`, the apply returns right after the write and `state` reflects the API response. Defaults to `true`", resourceName),
+		Type:        schema.TypeBool,
+		Optional:    true,
+		Default:     true,
+	}
+}
+
+func acceptStatesSchema(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Final provisioning states that complete the apply for the %s, one of `active` or `outdated`. `active` is always accepted; an accepted `outdated` state is reported as a warning. Defaults to `[\"active\"]`", resourceName),
+		Type:        schema.TypeSet,
+		Optional:    true,
+		Elem: &schema.Schema{
+			Type:         schema.TypeString,
+			ValidateFunc: validation.StringInSlice(provisioningAcceptableStates, false),
+		},
+	}
+}
+
+// provisioningAcceptableStates are the final states accept_states can list.
+// A failed Proxy or Reactor does not work, so failed always fails the apply.
+var provisioningAcceptableStates = []string{"active", "outdated"}
+
+// provisioningAcceptedStates returns the final states that complete an apply.
+func provisioningAcceptedStates(data *schema.ResourceData) []string {
+	acceptedStates := []string{"active"}
+
+	if states, ok := data.Get(acceptStatesKey).(*schema.Set); ok {
+		for _, state := range states.List() {
+			if state != "active" {
+				acceptedStates = append(acceptedStates, state.(string))
+			}
+		}
+	}
+
+	return acceptedStates
+}
+
+// provisioningWarnings reports the diagnostics of an accepted, non-active
+// final state as warnings so they stay visible without failing the apply.
+func provisioningWarnings(diags diag.Diagnostics) diag.Diagnostics {
+	for i := range diags {
+		diags[i].Severity = diag.Warning
+	}
+
+	return diags
+}
+
+func validateDuration(val interface{}, key string) (warns []string, errs []error) {
+	value, ok := val.(string)
+	if !ok {
+		errs = append(errs, fmt.Errorf("expected %s to be a string", key))
+		return
+	}
+
+	if _, err := time.ParseDuration(value); err != nil {
+		errs = append(errs, fmt.Errorf("%s must be a valid duration (e.g. 10m): %s", key, err))
+	}
+
+	return
+}
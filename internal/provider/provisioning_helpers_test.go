@@ -0,0 +1,80 @@
+package provider
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestProvisioningPollInterval_backsOffWithJitter(t *testing.T) {
+	for attempt, expectedInterval := range []time.Duration{
+		1 * time.Second,
+		2 * time.Second,
+		4 * time.Second,
+		8 * time.Second,
+		16 * time.Second,
+		30 * time.Second,
+		30 * time.Second,
+	} {
+		for i := 0; i < 50; i++ {
+			actual := provisioningPollInterval(attempt)
+
+			if actual < expectedInterval/2 || actual > expectedInterval {
+				t.Fatalf("attempt %d: expected interval between %s and %s, got %s", attempt, expectedInterval/2, expectedInterval, actual)
+			}
+		}
+	}
+}
+
+func TestValidateDuration(t *testing.T) {
+	if _, errs := validateDuration("15m", "provisioning_timeout"); len(errs) != 0 {
+		t.Fatalf("expected 15m to be valid, got %v", errs)
+	}
+
+	if _, errs := validateDuration("fifteen minutes", "provisioning_timeout"); len(errs) != 1 {
+		t.Fatalf("expected invalid duration to be rejected, got %v", errs)
+	}
+}
+
+func TestProvisioningAcceptedStates(t *testing.T) {
+	data := resourceBasisTheoryReactor().Data(nil)
+
+	if actual := provisioningAcceptedStates(data); !reflect.DeepEqual(actual, []string{"active"}) {
+		t.Fatalf("expected only active to be accepted by default, got %v", actual)
+	}
+
+	if err := data.Set(acceptStatesKey, []interface{}{"outdated", "active"}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if actual := provisioningAcceptedStates(data); !reflect.DeepEqual(actual, []string{"active", "outdated"}) {
+		t.Fatalf("expected active and outdated to be accepted, got %v", actual)
+	}
+}
+
+func TestAcceptStatesSchema_rejectsFailed(t *testing.T) {
+	validate := acceptStatesSchema("Reactor").Elem.(*schema.Schema).ValidateFunc
+
+	for _, state := range []string{"active", "outdated"} {
+		if _, errs := validate(state, acceptStatesKey); len(errs) != 0 {
+			t.Fatalf("expected %s to be accepted, got %v", state, errs)
+		}
+	}
+	if _, errs := validate("failed", acceptStatesKey); len(errs) != 1 {
+		t.Fatalf("expected failed to be rejected, got %v", errs)
+	}
+}
+
+func TestProvisioningWarnings(t *testing.T) {
+	actual := provisioningWarnings(diag.Errorf("reactor rct_123 reached outdated state"))
+
+	if actual.HasError() {
+		t.Fatalf("expected only warnings, got %#v", actual)
+	}
+	if len(actual) != 1 || actual[0].Summary != "reactor rct_123 reached outdated state" {
+		t.Fatalf("expected the original summary to be kept, got %#v", actual)
+	}
+}
@@ -124,7 +124,7 @@ func resourceApplePayMerchantCertificatesCreate(ctx context.Context, data *schem
 
 	cert, err := btClient.ApplePay.Merchant.Certificates.Create(ctx, merchantRegistrationID, request)
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Apple Pay Merchant Certificate:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Apple Pay Merchant Certificate:", err, resourceBasisTheoryApplePayMerchantCertificates().Schema)
 	}
 
 	data.SetId(*cert.ID)
@@ -9,8 +9,6 @@ import (
 	"testing"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -81,10 +79,7 @@ resource "basistheory_apple_pay_merchant_certificates" "terraform_test_apple_pay
 }
 
 func testAccCheckApplePayMerchantCertificatesDestroy(s *terraform.State) error {
-	btClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	btClient := newTestAPIClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "basistheory_apple_pay_merchant_certificates" {
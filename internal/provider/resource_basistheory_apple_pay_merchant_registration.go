@@ -58,7 +58,7 @@ func resourceApplePayMerchantRegistrationCreate(ctx context.Context, data *schem
 		MerchantIdentifier: &merchantIdentifier,
 	})
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Apple Pay Merchant Registration:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Apple Pay Merchant Registration:", err, resourceBasisTheoryApplePayMerchantRegistration().Schema)
 	}
 
 	data.SetId(*merchant.ID)
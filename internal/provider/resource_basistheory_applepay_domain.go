@@ -1,22 +1,26 @@
 package provider
 
 import (
-	"bytes"
 	"context"
-	"encoding/json"
-	"net/http"
 
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	applepay "github.com/Basis-Theory/go-sdk/v7/applepay"
 	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceApplePayDomain() *schema.Resource {
 	return &schema.Resource{
+		Description: "Manages the complete list of Apple Pay domains registered for the Tenant. Use `basistheory_applepay_domain_registration` to register individual domains without taking ownership of the others; the two should not manage the same Tenant",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		CreateContext: resourceApplePayDomainCreate,
 		ReadContext:   resourceApplePayDomainRead,
-		UpdateContext: resourceApplePayDomainCreate,
+		UpdateContext: resourceApplePayDomainUpdate,
 		DeleteContext: resourceApplePayDomainDelete,
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -37,25 +41,13 @@ func resourceApplePayDomain() *schema.Resource {
 }
 
 func resourceApplePayDomainCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
-
-	var domains []string
-	if dataEvents, ok := data.Get("domains").(*schema.Set); ok {
-		for _, domain := range dataEvents.List() {
-			domains = append(domains, domain.(string))
-		}
-	}
-	request := &applepay.ApplePayDomainRegistrationListRequest{
-		Domains: domains,
-	}
-
-	_, err := basisTheoryClient.ApplePay.Domain.RegisterAll(ctx, request)
-	if err != nil {
-		return apiErrorDiagnostics("Error registering Apple Pay domain:", err)
+	if diags := registerApplePayDomains(ctx, data, meta); diags != nil {
+		return diags
 	}
 
 	data.SetId("applepayDomains")
-	return nil
+
+	return resourceApplePayDomainRead(ctx, data, meta)
 }
 
 func resourceApplePayDomainRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -68,62 +60,81 @@ func resourceApplePayDomainRead(ctx context.Context, data *schema.ResourceData,
 
 	data.SetId("applepayDomains")
 
-	domains := response.GetDomains()
-	if domains == nil {
-		return apiErrorDiagnostics("No Apple Pay domains retrieved:", nil)
-	}
-
-	if err != nil {
+	if err := data.Set("domains", flattenApplePayDomains(response)); err != nil {
 		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
+func resourceApplePayDomainUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if data.HasChange("domains") {
+		if diags := registerApplePayDomains(ctx, data, meta); diags != nil {
+			return diags
+		}
+	}
+
+	return resourceApplePayDomainRead(ctx, data, meta)
+}
+
 func resourceApplePayDomainDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
-	// This function calls the raw HTTP API because the `go-sdk` defines the ApplePayDomainRegistrationListRequest
-	// with "omitempty" for the "Domains" attribute.
-	// That results in an HTTP 400 since the Domains attribute is not included in the HTTP call. :/
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	if err := registerAllApplePayDomains(ctx, basisTheoryClient, []string{}); err != nil {
+		return apiErrorDiagnostics("Error deregistering Apple Pay domains:", err)
+	}
 
-	// 	type ApplePayDomainRegistrationListRequest struct {
-	//		Domains []string `json:"domains,omitempty" url:"-"`
-	//	}
+	return nil
+}
 
-	body := map[string]interface{}{
-		"domains": []string{},
+// registerApplePayDomains replaces the Tenant's registered domains with the
+// configured set.
+func registerApplePayDomains(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	var domains []string
+	if dataDomains, ok := data.Get("domains").(*schema.Set); ok {
+		for _, domain := range dataDomains.List() {
+			domains = append(domains, domain.(string))
+		}
 	}
 
-	// Marshaling the request body to JSON
-	jsonData, err := json.Marshal(body)
-	if err != nil {
-		return apiErrorDiagnostics("Error deregistering Apple Pay domains:", err)
+	if err := registerAllApplePayDomains(ctx, basisTheoryClient, domains); err != nil {
+		return apiErrorDiagnosticsWithAttributePaths("Error registering Apple Pay domain:", err, resourceApplePayDomain().Schema)
 	}
 
-	// Create a new HTTP request
-	url := meta.(map[string]interface{})["api_url"].(string)
-	req, err := http.NewRequestWithContext(context.Background(), http.MethodPut, url+"/apple-pay/domain-registration", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return apiErrorDiagnostics("Error deregistering Apple Pay domains:", err)
+	return nil
+}
+
+// registerAllApplePayDomains replaces the Tenant's registered domains.
+// ApplePayDomainRegistrationListRequest tags Domains with "omitempty", so an
+// empty list would be dropped from the body and rejected by the API; the body
+// property puts it back so every domain can be deregistered.
+func registerAllApplePayDomains(ctx context.Context, client *basistheoryClient.Client, domains []string) error {
+	if domains == nil {
+		domains = []string{}
 	}
 
-	// Set headers
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("BT-API-KEY", meta.(map[string]interface{})["api_key"].(string))
+	_, err := client.ApplePay.Domain.RegisterAll(ctx, &applepay.ApplePayDomainRegistrationListRequest{
+		Domains: domains,
+	}, option.WithBodyProperties(map[string]interface{}{
+		"domains": domains,
+	}))
 
-	// Execute the request
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return apiErrorDiagnostics("Error deregistering Apple Pay domains:", err)
+	return err
+}
+
+func flattenApplePayDomains(response *basistheory.ApplePayDomainRegistrationResponse) []interface{} {
+	domains := []interface{}{}
+	if response == nil {
+		return domains
 	}
-	defer resp.Body.Close()
 
-	// Check response status
-	if resp.StatusCode == http.StatusOK {
-		return nil
-	} else {
-		return apiErrorDiagnostics("Error deregistering Apple Pay domains:", err)
+	for _, domain := range response.GetDomains() {
+		if domain != nil && getStringValue(domain.GetDomain()) != "" {
+			domains = append(domains, getStringValue(domain.GetDomain()))
+		}
 	}
 
-	return nil
+	return domains
 }
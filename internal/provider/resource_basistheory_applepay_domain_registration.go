@@ -0,0 +1,159 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const applePayDomainRegistrationMaxAttempts = 5
+
+// applePayDomainsMutex serializes the read-modify-write of the Tenant's domain
+// list between resources applied in parallel by the same provider instance.
+var applePayDomainsMutex sync.Mutex
+
+func resourceBasisTheoryApplePayDomainRegistration() *schema.Resource {
+	return &schema.Resource{
+		Description: "Registers a single Apple Pay domain without taking ownership of the Tenant's other domains https://developers.basistheory.com/docs/api/apple-pay/api#apple-pay-domain-registration. The API only replaces the whole domain list, so registrations are serialized within a single Terraform run only. Applies running at the same time in other workspaces, or other tools changing the list, can undo each other's changes: a domain removed by one may be written back by another",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CreateContext: resourceApplePayDomainRegistrationCreate,
+		ReadContext:   resourceApplePayDomainRegistrationRead,
+		DeleteContext: resourceApplePayDomainRegistrationDelete,
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "The registered domain",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"domain": {
+				Description: "Public domain of a hosted application",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+		},
+	}
+}
+
+func resourceApplePayDomainRegistrationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	domain := data.Get("domain").(string)
+
+	if diags := setApplePayDomainRegistered(ctx, meta, domain, true); diags != nil {
+		return diags
+	}
+
+	data.SetId(domain)
+
+	return resourceApplePayDomainRegistrationRead(ctx, data, meta)
+}
+
+func resourceApplePayDomainRegistrationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	response, err := basisTheoryClient.ApplePay.Domain.Get(ctx)
+	if err != nil {
+		return apiErrorDiagnostics("Error reading Apple Pay domains:", err)
+	}
+
+	if !containsString(applePayDomainStrings(flattenApplePayDomains(response)), data.Id()) {
+		data.SetId("")
+		return nil
+	}
+
+	if err := data.Set("domain", data.Id()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceApplePayDomainRegistrationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return setApplePayDomainRegistered(ctx, meta, data.Id(), false)
+}
+
+// setApplePayDomainRegistered adds or removes a single domain while keeping
+// every other registration in place. The API only replaces the whole list and
+// has no conditional update, so the current list is re-read before each write
+// and the change to domain is retried if another writer drops it. Changes
+// other writers make to the rest of the list between the read and the write
+// are still lost.
+func setApplePayDomainRegistered(ctx context.Context, meta interface{}, domain string, registered bool) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	applePayDomainsMutex.Lock()
+	defer applePayDomainsMutex.Unlock()
+
+	for attempt := 0; ; attempt++ {
+		response, err := basisTheoryClient.ApplePay.Domain.Get(ctx)
+		if err != nil {
+			return apiErrorDiagnostics("Error reading Apple Pay domains:", err)
+		}
+
+		current := applePayDomainStrings(flattenApplePayDomains(response))
+		if containsString(current, domain) == registered {
+			return nil
+		}
+
+		if attempt >= applePayDomainRegistrationMaxAttempts {
+			return diag.Errorf("Apple Pay domain %s was not %s after %d attempts; another process may be modifying the Tenant's domains", domain, applePayDomainRegistrationAction(registered), attempt)
+		}
+
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					return diag.Errorf("timeout waiting for Apple Pay domain %s to be %s", domain, applePayDomainRegistrationAction(registered))
+				}
+				return diag.FromErr(ctx.Err())
+			case <-time.After(provisioningPollInterval(attempt - 1)):
+			}
+		}
+
+		if err := registerAllApplePayDomains(ctx, basisTheoryClient, withApplePayDomain(current, domain, registered)); err != nil {
+			return apiErrorDiagnosticsWithAttributePaths("Error registering Apple Pay domain:", err, resourceBasisTheoryApplePayDomainRegistration().Schema)
+		}
+	}
+}
+
+// withApplePayDomain returns a copy of domains with domain added or removed.
+func withApplePayDomain(domains []string, domain string, registered bool) []string {
+	result := []string{}
+	for _, existing := range domains {
+		if existing != domain {
+			result = append(result, existing)
+		}
+	}
+
+	if registered {
+		result = append(result, domain)
+	}
+
+	return result
+}
+
+func applePayDomainStrings(domains []interface{}) []string {
+	var result []string
+	for _, domain := range domains {
+		result = append(result, domain.(string))
+	}
+
+	return result
+}
+
+func applePayDomainRegistrationAction(registered bool) string {
+	if registered {
+		return "registered"
+	}
+
+	return "deregistered"
+}
@@ -0,0 +1,113 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestApplePayDomainRegistration(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckApplePayDomainRegistrationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testApplePayDomainRegistrationCreate,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_applepay_domain_registration.flock_dev", "id", "cdn.flock-dev.com"),
+					resource.TestCheckResourceAttr(
+						"basistheory_applepay_domain_registration.flock_dev", "domain", "cdn.flock-dev.com"),
+					resource.TestCheckResourceAttr(
+						"basistheory_applepay_domain_registration.basis_theory", "domain", "cdn.basistheory.com"),
+					testAccCheckApplePayDomainRegistered("cdn.flock-dev.com", true),
+					testAccCheckApplePayDomainRegistered("cdn.basistheory.com", true),
+				),
+			},
+			{
+				ResourceName:      "basistheory_applepay_domain_registration.flock_dev",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				Config: testApplePayDomainRegistrationRemoveOne,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					testAccCheckApplePayDomainRegistered("cdn.flock-dev.com", false),
+					testAccCheckApplePayDomainRegistered("cdn.basistheory.com", true),
+				),
+			},
+		},
+	})
+}
+
+func TestWithApplePayDomain(t *testing.T) {
+	domains := []string{"a.example.com", "b.example.com"}
+
+	if actual := withApplePayDomain(domains, "c.example.com", true); !reflect.DeepEqual(actual, []string{"a.example.com", "b.example.com", "c.example.com"}) {
+		t.Fatalf("expected domain to be added, got %v", actual)
+	}
+	if actual := withApplePayDomain(domains, "a.example.com", true); !reflect.DeepEqual(actual, []string{"b.example.com", "a.example.com"}) {
+		t.Fatalf("expected domain not to be duplicated, got %v", actual)
+	}
+	if actual := withApplePayDomain(domains, "a.example.com", false); !reflect.DeepEqual(actual, []string{"b.example.com"}) {
+		t.Fatalf("expected domain to be removed, got %v", actual)
+	}
+	if actual := withApplePayDomain([]string{"a.example.com"}, "a.example.com", false); actual == nil || len(actual) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", actual)
+	}
+	if !reflect.DeepEqual(domains, []string{"a.example.com", "b.example.com"}) {
+		t.Fatalf("expected input to be left untouched, got %v", domains)
+	}
+}
+
+func testAccCheckApplePayDomainRegistered(domain string, registered bool) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		basisTheoryClient := newTestAPIClient()
+
+		response, err := basisTheoryClient.ApplePay.Domain.Get(context.TODO())
+		if err != nil {
+			return err
+		}
+
+		if containsString(applePayDomainStrings(flattenApplePayDomains(response)), domain) != registered {
+			return fmt.Errorf("expected Apple Pay domain %s to be %s", domain, applePayDomainRegistrationAction(registered))
+		}
+
+		return nil
+	}
+}
+
+func testAccCheckApplePayDomainRegistrationDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "basistheory_applepay_domain_registration" {
+			continue
+		}
+
+		if err := testAccCheckApplePayDomainRegistered(rs.Primary.ID, false)(s); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const testApplePayDomainRegistrationCreate = `
+resource "basistheory_applepay_domain_registration" "flock_dev" {
+	domain = "cdn.flock-dev.com"
+}
+
+resource "basistheory_applepay_domain_registration" "basis_theory" {
+	domain = "cdn.basistheory.com"
+}
+`
+
+const testApplePayDomainRegistrationRemoveOne = `
+resource "basistheory_applepay_domain_registration" "basis_theory" {
+	domain = "cdn.basistheory.com"
+}
+`
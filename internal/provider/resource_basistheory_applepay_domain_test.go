@@ -3,11 +3,10 @@ package provider
 import (
 	"context"
 	"fmt"
-	"os"
 	"testing"
 
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	"github.com/Basis-Theory/go-sdk/v7/applepay"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -25,6 +24,17 @@ func TestApplePayDomainMultiple(t *testing.T) {
 						"basistheory_applepay_domain.terraform_test_apple_pay_domain", "domains.0", "cdn.flock-dev.com"),
 				),
 			},
+			{
+				ResourceName:      "basistheory_applepay_domain.terraform_test_apple_pay_domain",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
+			{
+				PreConfig:          registerApplePayDomainsExternally(t, "cdn.flock-dev.com", "cdn.basistheory.com"),
+				Config:             testApplePayDomainRegisterCreate,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
 			{
 				Config: testApplePayDomainRegisterUpdateToMany,
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -43,11 +53,40 @@ func TestApplePayDomainMultiple(t *testing.T) {
 	})
 }
 
+func TestFlattenApplePayDomains(t *testing.T) {
+	response := &basistheory.ApplePayDomainRegistrationResponse{
+		Domains: []*basistheory.DomainRegistrationResponse{
+			{Domain: getStringPointer("cdn.flock-dev.com")},
+			nil,
+			{Domain: getStringPointer("cdn.basistheory.com")},
+		},
+	}
+
+	actual := flattenApplePayDomains(response)
+
+	if len(actual) != 2 || actual[0] != "cdn.flock-dev.com" || actual[1] != "cdn.basistheory.com" {
+		t.Fatalf("unexpected domains: %v", actual)
+	}
+	if actual := flattenApplePayDomains(nil); len(actual) != 0 {
+		t.Fatalf("expected no domains for an empty response, got %v", actual)
+	}
+}
+
+func registerApplePayDomainsExternally(t *testing.T, domains ...string) func() {
+	return func() {
+		basisTheoryClient := newTestAPIClient()
+
+		_, err := basisTheoryClient.ApplePay.Domain.RegisterAll(context.TODO(), &applepay.ApplePayDomainRegistrationListRequest{
+			Domains: domains,
+		})
+		if err != nil {
+			t.Fatalf("failed to register Apple Pay domains: %s", err)
+		}
+	}
+}
+
 func testAccCheckApplePayDomainDestroy(s *terraform.State) error {
-	basisTheoryClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	basisTheoryClient := newTestAPIClient()
 
 	response, err := basisTheoryClient.ApplePay.Domain.Get(context.TODO())
 	if err != nil {
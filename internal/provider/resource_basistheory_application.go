@@ -3,9 +3,12 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"regexp"
@@ -15,6 +18,7 @@ func resourceBasisTheoryApplication() *schema.Resource {
 	var (
 		applicationTypes     = []string{"public", "private", "management"}
 		accessRuleTransforms = []string{"mask", "redact", "reveal"}
+		accessRuleOperators  = []string{"starts_with", "equals", "not_equals"}
 	)
 
 	return &schema.Resource{
@@ -24,11 +28,16 @@ func resourceBasisTheoryApplication() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		CreateContext: resourceApplicationCreate,
+		CreateContext: warnUnknownPermissions(resourceApplicationCreate, applicationPermissionsFromData),
 		ReadContext:   resourceApplicationRead,
-		UpdateContext: resourceApplicationUpdate,
+		UpdateContext: warnUnknownPermissions(resourceApplicationUpdate, applicationPermissionsFromData),
 		DeleteContext: resourceApplicationDelete,
 
+		CustomizeDiff: customdiff.All(
+			resourceApplicationCustomizeDiff,
+			resourceApplicationPermissionsCustomizeDiff,
+		),
+
 		Schema: map[string]*schema.Schema{
 			"id": {
 				Description: "Unique identifier for the Application",
@@ -90,9 +99,35 @@ func resourceBasisTheoryApplication() *schema.Resource {
 							ValidateFunc: validation.IntAtLeast(1),
 						},
 						"container": {
-							Description: "The container of Tokens this rule is scoped to",
+							Description: "The container of Tokens this rule is scoped to. Exactly one of `container` or `conditions` must be set",
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
+						},
+						"conditions": {
+							Description: "Conditions on Token attributes this rule is scoped to. All of them must match. Exactly one of `container` or `conditions` must be set",
+							Type:        schema.TypeList,
+							Optional:    true,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"attribute": {
+										Description:  "The Token attribute to match, one of `container`, `type` or `metadata.<key>`",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringMatch(regexp.MustCompile(`^(container|type|metadata\..+)$`), "attribute must be one of container, type or metadata.<key>"),
+									},
+									"operator": {
+										Description:  "How the attribute is compared to the value, one of `starts_with`, `equals` or `not_equals`",
+										Type:         schema.TypeString,
+										Required:     true,
+										ValidateFunc: validation.StringInSlice(accessRuleOperators, false),
+									},
+									"value": {
+										Description: "The value to compare the attribute to",
+										Type:        schema.TypeString,
+										Required:    true,
+									},
+								},
+							},
 						},
 						"transform": {
 							Description:  "The transform to apply to accessed Tokens",
@@ -131,6 +166,7 @@ func resourceBasisTheoryApplication() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			deletionProtectionKey: deletionProtectionSchema("Application"),
 		},
 		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
@@ -177,7 +213,7 @@ func resourceApplicationCreate(ctx context.Context, data *schema.ResourceData, m
 	createdApplication, err := basisTheoryClient.Applications.Create(ctx, createApplicationRequest)
 
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Application:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Application:", err, resourceBasisTheoryApplication().Schema)
 	}
 
 	data.SetId(*createdApplication.ID)
@@ -233,6 +269,8 @@ func resourceApplicationRead(ctx context.Context, data *schema.ResourceData, met
 		"created_by":  application.CreatedBy,
 		"modified_at": modifiedAt,
 		"modified_by": application.ModifiedBy,
+		// Keeps the default in state for imported Applications
+		deletionProtectionKey: data.Get(deletionProtectionKey),
 	} {
 		err := data.Set(applicationDatumName, applicationDatum)
 
@@ -245,6 +283,11 @@ func resourceApplicationRead(ctx context.Context, data *schema.ResourceData, met
 }
 
 func resourceApplicationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// deletion_protection only lives in Terraform state
+	if !data.HasChangesExcept(deletionProtectionKey) {
+		return nil
+	}
+
 	if data.HasChange("create_key") {
 		oldCreateKey, _ := data.GetChange("create_key")
 		err := data.Set("create_key", oldCreateKey)
@@ -269,13 +312,17 @@ func resourceApplicationUpdate(ctx context.Context, data *schema.ResourceData, m
 	_, err := basisTheoryClient.Applications.Update(ctx, getStringValue(application.ID), updateApplicationRequest)
 
 	if err != nil {
-		return apiErrorDiagnostics("Error updating Application:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error updating Application:", err, resourceBasisTheoryApplication().Schema)
 	}
 
 	return resourceApplicationRead(ctx, data, meta)
 }
 
 func resourceApplicationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := deletionProtectionDiagnostics(data, "Application"); diags != nil {
+		return diags
+	}
+
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
 	err := basisTheoryClient.Applications.Delete(ctx, data.Id())
@@ -309,10 +356,13 @@ func getApplicationFromData(data *schema.ResourceData) basistheory.Application {
 			rule := &basistheory.AccessRule{
 				Description: getStringPointer(ruleMap["description"]),
 				Priority:    getIntPointer(ruleMap["priority"]),
-				Container:   getStringPointer(ruleMap["container"]),
 				Transform:   getStringPointer(ruleMap["transform"]),
+				Conditions:  expandAccessRuleConditions(ruleMap["conditions"]),
 				Permissions: rulePermissions,
 			}
+			if container, _ := ruleMap["container"].(string); container != "" {
+				rule.Container = &container
+			}
 
 			rules = append(rules, rule)
 		}
@@ -342,6 +392,7 @@ func flattenAccessRuleData(accessRules []*basistheory.AccessRule) []interface{}
 			flattenedAccessRule["priority"] = rule.Priority
 			flattenedAccessRule["container"] = rule.Container
 			flattenedAccessRule["transform"] = rule.Transform
+			flattenedAccessRule["conditions"] = flattenAccessRuleConditions(rule.Conditions)
 			flattenedAccessRule["permissions"] = rule.Permissions
 
 			flattenedAccessRules = append(flattenedAccessRules, flattenedAccessRule)
@@ -352,3 +403,97 @@ func flattenAccessRuleData(accessRules []*basistheory.AccessRule) []interface{}
 
 	return make([]interface{}, 0)
 }
+
+func expandAccessRuleConditions(dataConditions interface{}) []*basistheory.Condition {
+	var conditions []*basistheory.Condition
+	for _, dataCondition := range dataConditions.([]interface{}) {
+		conditionMap := dataCondition.(map[string]interface{})
+		conditions = append(conditions, &basistheory.Condition{
+			Attribute: getStringPointer(conditionMap["attribute"]),
+			Operator:  getStringPointer(conditionMap["operator"]),
+			Value:     getStringPointer(conditionMap["value"]),
+		})
+	}
+
+	return conditions
+}
+
+func flattenAccessRuleConditions(conditions []*basistheory.Condition) []interface{} {
+	flattenedConditions := make([]interface{}, 0, len(conditions))
+	for _, condition := range conditions {
+		flattenedConditions = append(flattenedConditions, map[string]interface{}{
+			"attribute": getStringValue(condition.GetAttribute()),
+			"operator":  getStringValue(condition.GetOperator()),
+			"value":     getStringValue(condition.GetValue()),
+		})
+	}
+
+	return flattenedConditions
+}
+
+// resourceApplicationCustomizeDiff checks at plan time that every access rule
+// is scoped by either a container or conditions.
+func resourceApplicationCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("rule") {
+		return nil
+	}
+
+	rules, ok := diff.Get("rule").(*schema.Set)
+	if !ok {
+		return nil
+	}
+
+	for _, rule := range rules.List() {
+		ruleMap := rule.(map[string]interface{})
+		container, _ := ruleMap["container"].(string)
+		conditions, _ := ruleMap["conditions"].([]interface{})
+
+		if (container == "") == (len(conditions) == 0) {
+			return cty.GetAttrPath("rule").NewError(fmt.Errorf("access rule %q must set exactly one of container or conditions", ruleMap["description"]))
+		}
+	}
+
+	return nil
+}
+
+// resourceApplicationPermissionsCustomizeDiff checks at plan time that the
+// Application and its access rules are only granted permissions its type can
+// hold, suggesting the closest known permission for likely typos when the
+// catalog is refreshed.
+func resourceApplicationPermissionsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("type") {
+		return nil
+	}
+
+	catalog, refreshed := permissionCatalogFromMeta(meta)
+	applicationType := diff.Get("type").(string)
+
+	var errs []error
+	if permissions, ok := diff.Get("permissions").(*schema.Set); ok && diff.NewValueKnown("permissions") {
+		errs = append(errs, catalog.validatePermissions(applicationType, permissions.List(), cty.GetAttrPath("permissions"), false, refreshed)...)
+	}
+
+	if rules, ok := diff.Get("rule").(*schema.Set); ok && diff.NewValueKnown("rule") {
+		for _, rule := range rules.List() {
+			permissions, _ := rule.(map[string]interface{})["permissions"].(*schema.Set)
+			if permissions != nil {
+				errs = append(errs, catalog.validatePermissions(applicationType, permissions.List(), cty.GetAttrPath("rule"), false, refreshed)...)
+			}
+		}
+	}
+
+	return joinPathErrors(errs)
+}
+
+// applicationPermissionsFromData returns the permissions granted to the
+// Application and by its access rules.
+func applicationPermissionsFromData(data *schema.ResourceData) (string, []interface{}) {
+	permissions := data.Get("permissions").(*schema.Set).List()
+	for _, rule := range data.Get("rule").(*schema.Set).List() {
+		if rulePermissions, ok := rule.(map[string]interface{})["permissions"].(*schema.Set); ok {
+			permissions = append(permissions, rulePermissions.List()...)
+		}
+	}
+
+	return data.Get("type").(string), permissions
+}
@@ -3,6 +3,7 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
@@ -15,7 +16,7 @@ func resourceBasisTheoryApplicationKey() *schema.Resource {
 		Description: "Application Keys https://developers.basistheory.com/docs/api/applications/keys",
 
 		Importer: &schema.ResourceImporter{
-			StateContext: schema.ImportStatePassthroughContext,
+			StateContext: resourceApplicationKeyImport,
 		},
 
 		CreateContext: resourceApplicationKeyCreate,
@@ -62,7 +63,7 @@ func resourceApplicationKeyCreate(ctx context.Context, data *schema.ResourceData
 	createdApplicationKey, err := basisTheoryClient.ApplicationKeys.Create(ctx, applicationId)
 
 	if err != nil {
-		return apiErrorDiagnostics("Error creating ApplicationKey:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating ApplicationKey:", err, resourceBasisTheoryApplicationKey().Schema)
 	}
 
 	data.SetId(*createdApplicationKey.ID)
@@ -87,6 +88,7 @@ func resourceApplicationKeyRead(ctx context.Context, data *schema.ResourceData,
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
 	applicationId := data.Get("application_id").(string)
+	imported := data.Get("created_at").(string) == "" && data.Get("key").(string) == ""
 	applicationKey, err := basisTheoryClient.ApplicationKeys.Get(ctx, applicationId, data.Id())
 
 	if err != nil {
@@ -115,9 +117,33 @@ func resourceApplicationKeyRead(ctx context.Context, data *schema.ResourceData,
 		}
 	}
 
+	if imported {
+		return diag.Diagnostics{diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Application Key imported without its key",
+			Detail:   fmt.Sprintf("The key for Application Key %s is only returned when it is created, so it is not available in state after import. Create a new basistheory_application_key if the key is needed.", data.Id()),
+		}}
+	}
+
 	return nil
 }
 
+// resourceApplicationKeyImport accepts IDs in the form
+// <application_id>/<key_id>, since reading a key requires its Application.
+func resourceApplicationKeyImport(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(data.Id(), "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected <application_id>/<key_id>", data.Id())
+	}
+
+	if err := data.Set("application_id", idParts[0]); err != nil {
+		return nil, err
+	}
+	data.SetId(idParts[1])
+
+	return []*schema.ResourceData{data}, nil
+}
+
 func resourceApplicationKeyUpdate(_ context.Context, data *schema.ResourceData, _ interface{}) diag.Diagnostics {
 	oldAppId, _ := data.GetChange("application_id")
 
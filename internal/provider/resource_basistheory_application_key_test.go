@@ -5,12 +5,10 @@ import (
 	"errors"
 	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"os"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -34,6 +32,13 @@ func TestResourceApplicationKey(t *testing.T) {
 						"basistheory_application_key.terraform_test_application_key", "application_id", regexp.MustCompile(testUuidRegex)),
 				),
 			},
+			{
+				ResourceName:            "basistheory_application_key.terraform_test_application_key",
+				ImportState:             true,
+				ImportStateIdFunc:       testAccApplicationKeyImportStateId("basistheory_application_key.terraform_test_application_key"),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"key"},
+			},
 			{
 				Config:      fmt.Sprintf("%s\n%s", formattedTestAccApplicationCreate, formattedTestAccApplicationKeyUpdate),
 				ExpectError: regexp.MustCompile(`Updating ApplicationKey is not supported`),
@@ -77,6 +82,47 @@ func TestResourceApplicationKey_HandlesGraceful404(t *testing.T) {
 	})
 }
 
+func TestResourceApplicationKey_ImportRejectsMalformedId(t *testing.T) {
+	for _, id := range []string{"key-id", "/key-id", "application-id/", "application-id/key-id/extra"} {
+		data := resourceBasisTheoryApplicationKey().Data(nil)
+		data.SetId(id)
+
+		_, err := resourceApplicationKeyImport(context.Background(), data, nil)
+
+		if err == nil || !strings.Contains(err.Error(), "expected <application_id>/<key_id>") {
+			t.Fatalf("expected malformed ID %q to be rejected, got %v", id, err)
+		}
+	}
+}
+
+func TestResourceApplicationKey_ImportSetsApplicationId(t *testing.T) {
+	data := resourceBasisTheoryApplicationKey().Data(nil)
+	data.SetId("application-id/key-id")
+
+	imported, err := resourceApplicationKeyImport(context.Background(), data, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if imported[0].Id() != "key-id" {
+		t.Fatalf("expected id to be key-id, got %s", imported[0].Id())
+	}
+	if actual := imported[0].Get("application_id"); actual != "application-id" {
+		t.Fatalf("expected application_id to be application-id, got %s", actual)
+	}
+}
+
+func testAccApplicationKeyImportStateId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["application_id"], rs.Primary.ID), nil
+	}
+}
+
 func deleteApplicationKeyExternally(resourceName string) resource.TestCheckFunc {
 	return func(s *terraform.State) error {
 		rs, ok := s.RootModule().Resources[resourceName]
@@ -84,10 +130,7 @@ func deleteApplicationKeyExternally(resourceName string) resource.TestCheckFunc
 			return fmt.Errorf("resource not found: %s", resourceName)
 		}
 
-		client := basistheoryClient.NewClient(
-			option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-			option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-		)
+		client := newTestAPIClient()
 
 		applicationId := rs.Primary.Attributes["application_id"]
 		return client.ApplicationKeys.Delete(context.TODO(), applicationId, rs.Primary.ID)
@@ -95,10 +138,7 @@ func deleteApplicationKeyExternally(resourceName string) resource.TestCheckFunc
 }
 
 func testAccCheckApplicationKeyDestroy(state *terraform.State) error {
-	basisTheoryClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	basisTheoryClient := newTestAPIClient()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "basistheory_application_key" {
@@ -5,13 +5,11 @@ import (
 	"errors"
 	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"os"
 	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -54,6 +52,35 @@ func TestResourceApplication(t *testing.T) {
 	})
 }
 
+func TestResourceApplicationWithDeletionProtection(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccApplicationWithDeletionProtection, "terraform_test_application", "true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_application.terraform_test_application", "deletion_protection", "true"),
+				),
+			},
+			{
+				Config:      fmt.Sprintf(testAccApplicationWithDeletionProtection, "terraform_test_application", "true"),
+				Destroy:     true,
+				ExpectError: regexp.MustCompile(`Application .* is protected from deletion`),
+			},
+			{
+				Config: fmt.Sprintf(testAccApplicationWithDeletionProtection, "terraform_test_application", "false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_application.terraform_test_application", "deletion_protection", "false"),
+				),
+			},
+		},
+	})
+}
+
 func TestResourceApplicationWithCreateKeyTrue(t *testing.T) {
 	testAccApplicationKey := ""
 
@@ -229,6 +256,95 @@ func TestResourceApplicationWithAccessRulesHavingInvalidTransform(t *testing.T)
 	})
 }
 
+func TestResourceApplicationWithAccessRuleConditions(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckApplicationDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccApplicationWithAccessRuleConditions,
+				Check: resource.TestCheckTypeSetElemNestedAttrs(
+					"basistheory_application.terraform_test_application", "rule.*", map[string]string{
+						"description":            "Reveal partner tokens",
+						"container":              "",
+						"conditions.#":           "2",
+						"conditions.0.attribute": "container",
+						"conditions.0.operator":  "starts_with",
+						"conditions.0.value":     "/pci/",
+						"conditions.1.attribute": "metadata.partner",
+						"conditions.1.operator":  "equals",
+						"conditions.1.value":     "acme",
+					}),
+			},
+		},
+	})
+}
+
+func TestResourceApplicationWithAccessRuleConditionsHavingInvalidOperator(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config:      strings.Replace(testAccApplicationWithAccessRuleConditions, `operator  = "equals"`, `operator  = "contains"`, 1),
+				ExpectError: regexp.MustCompile(`expected rule.0.conditions.1.operator to be one of \[starts_with equals not_equals], got contains`),
+			},
+		},
+	})
+}
+
+func TestResourceApplicationCustomizeDiff_requiresContainerOrConditions(t *testing.T) {
+	condition := map[string]interface{}{"attribute": "type", "operator": "equals", "value": "card"}
+
+	for name, testCase := range map[string]struct {
+		rule          map[string]interface{}
+		expectedError bool
+	}{
+		"container":  {map[string]interface{}{"container": "/"}, false},
+		"conditions": {map[string]interface{}{"conditions": []interface{}{condition}}, false},
+		"both":       {map[string]interface{}{"container": "/", "conditions": []interface{}{condition}}, true},
+		"neither":    {map[string]interface{}{}, true},
+	} {
+		rule := map[string]interface{}{
+			"description": "TEST_RULE",
+			"priority":    1,
+			"transform":   "reveal",
+			"permissions": []interface{}{"token:read"},
+		}
+		for key, value := range testCase.rule {
+			rule[key] = value
+		}
+
+		_, err := resourceBasisTheoryApplication().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":        "Terraform application",
+			"type":        "private",
+			"permissions": []interface{}{"token:read"},
+			"rule":        []interface{}{rule},
+		}), nil)
+
+		if testCase.expectedError != (err != nil) {
+			t.Fatalf("%s: expected error %t, got %v", name, testCase.expectedError, err)
+		}
+		if err != nil && !strings.Contains(err.Error(), `access rule "TEST_RULE" must set exactly one of container or conditions`) {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+	}
+}
+
+func TestFlattenAccessRuleData_includesConditions(t *testing.T) {
+	attribute, operator, value := "metadata.partner", "equals", "acme"
+
+	actual := flattenAccessRuleData([]*basistheory.AccessRule{{
+		Conditions: []*basistheory.Condition{{Attribute: &attribute, Operator: &operator, Value: &value}},
+	}})
+
+	expected := []interface{}{map[string]interface{}{"attribute": attribute, "operator": operator, "value": value}}
+	if conditions := actual[0].(map[string]interface{})["conditions"]; !reflect.DeepEqual(conditions, expected) {
+		t.Fatalf("expected conditions %v, got %v", expected, conditions)
+	}
+}
+
 const testAccApplicationCreate = `
 resource "basistheory_application" "%s" {
   name = "Terraform application"
@@ -246,6 +362,15 @@ resource "basistheory_application" "%s" {
 }
 `
 
+const testAccApplicationWithDeletionProtection = `
+resource "basistheory_application" "%s" {
+  name = "Terraform application"
+  type = "private"
+  permissions = ["token:read"]
+  deletion_protection = %s
+}
+`
+
 const testAccApplicationCreateWithInvalidPermission = `
 resource "basistheory_application" "terraform_test_application" {
   name = "Terraform application"
@@ -358,20 +483,14 @@ func deleteApplicationExternally(resourceName string) resource.TestCheckFunc {
 			return fmt.Errorf("resource not found: %s", resourceName)
 		}
 
-		client := basistheoryClient.NewClient(
-			option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-			option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-		)
+		client := newTestAPIClient()
 
 		return client.Applications.Delete(context.TODO(), rs.Primary.ID)
 	}
 }
 
 func testAccCheckApplicationDestroy(state *terraform.State) error {
-	basisTheoryClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	basisTheoryClient := newTestAPIClient()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "basistheory_application" {
@@ -461,3 +580,26 @@ func TestApplicationInstanceStateUpgradeV0(t *testing.T) {
 		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
 	}
 }
+
+const testAccApplicationWithAccessRuleConditions = `
+resource "basistheory_application" "terraform_test_application" {
+  name = "Terraform application"
+  type = "private"
+  rule {
+	description = "Reveal partner tokens"
+	priority = 1
+	transform = "reveal"
+	permissions = ["token:read"]
+	conditions {
+	  attribute = "container"
+	  operator  = "starts_with"
+	  value     = "/pci/"
+	}
+	conditions {
+	  attribute = "metadata.partner"
+	  operator  = "equals"
+	  value     = "acme"
+	}
+  }
+}
+`
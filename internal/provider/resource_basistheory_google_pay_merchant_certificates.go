@@ -3,6 +3,8 @@ package provider
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
@@ -15,6 +17,10 @@ func resourceBasisTheoryGooglePayMerchantCertificates() *schema.Resource {
 	return &schema.Resource{
 		Description: "Google Pay Merchant Registration Certificates https://developers.basistheory.com/docs/api/google-pay/api#google-pay-merchant-certificates",
 
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGooglePayMerchantCertificatesImport,
+		},
+
 		CreateContext: resourceGooglePayMerchantCertificatesCreate,
 		ReadContext:   resourceGooglePayMerchantCertificatesRead,
 		DeleteContext: resourceGooglePayMerchantCertificatesDelete,
@@ -32,18 +38,20 @@ func resourceBasisTheoryGooglePayMerchantCertificates() *schema.Resource {
 				ForceNew:    true,
 			},
 			"merchant_certificate_data": {
-				Description: "Base64-encoded PKCS#12 certificate data",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Sensitive:   true,
+				Description:      "Base64-encoded PKCS#12 certificate data. It cannot be read back from the API, so it is ignored after import",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressWriteOnlyDiffAfterImport,
 			},
 			"merchant_certificate_password": {
-				Description: "Password for the PKCS#12 certificate",
-				Type:        schema.TypeString,
-				Required:    true,
-				ForceNew:    true,
-				Sensitive:   true,
+				Description:      "Password for the PKCS#12 certificate. It cannot be read back from the API, so it is ignored after import",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressWriteOnlyDiffAfterImport,
 			},
 			"merchant_certificate_fingerprint": {
 				Description: "Fingerprint of the registered merchant certificate",
@@ -84,7 +92,7 @@ func resourceGooglePayMerchantCertificatesCreate(ctx context.Context, data *sche
 
 	cert, err := btClient.GooglePay.Merchant.Certificates.Create(ctx, merchantRegistrationID, request)
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Google Pay Merchant Certificate:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Google Pay Merchant Certificate:", err, resourceBasisTheoryGooglePayMerchantCertificates().Schema)
 	}
 
 	data.SetId(*cert.ID)
@@ -133,6 +141,29 @@ func resourceGooglePayMerchantCertificatesRead(ctx context.Context, data *schema
 	return nil
 }
 
+func resourceGooglePayMerchantCertificatesImport(_ context.Context, data *schema.ResourceData, _ interface{}) ([]*schema.ResourceData, error) {
+	idParts := strings.Split(data.Id(), "/")
+	if len(idParts) != 2 || idParts[0] == "" || idParts[1] == "" {
+		return nil, fmt.Errorf("unexpected format of ID (%q), expected <merchant_registration_id>/<certificate_id>", data.Id())
+	}
+
+	if err := data.Set("merchant_registration_id", idParts[0]); err != nil {
+		return nil, err
+	}
+	data.SetId(idParts[1])
+
+	return []*schema.ResourceData{data}, nil
+}
+
+// suppressWriteOnlyDiffAfterImport keeps inputs the API never returns from
+// forcing a replacement of an imported resource. Import leaves them empty in
+// state, so any configured value would otherwise look like a change. Once
+// imported, changing such an input has no effect until the resource is
+// replaced.
+func suppressWriteOnlyDiffAfterImport(_, old, _ string, data *schema.ResourceData) bool {
+	return old == "" && data.Id() != ""
+}
+
 func resourceGooglePayMerchantCertificatesDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	btClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
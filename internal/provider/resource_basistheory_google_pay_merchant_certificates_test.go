@@ -6,11 +6,10 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"strings"
 	"testing"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -37,6 +36,13 @@ func TestGooglePayMerchantCertificates(t *testing.T) {
 					resource.TestCheckResourceAttrSet(googlePayCertResourceName, "created_at"),
 				),
 			},
+			{
+				ResourceName:            googlePayCertResourceName,
+				ImportState:             true,
+				ImportStateIdFunc:       testAccGooglePayMerchantCertificatesImportStateId(googlePayCertResourceName),
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"merchant_certificate_data", "merchant_certificate_password"},
+			},
 			{
 				Config: testAccGooglePayMerchantCertificatesConfig("terraform-test-google-merchant-2"),
 				Check: resource.ComposeAggregateTestCheckFunc(
@@ -47,6 +53,97 @@ func TestGooglePayMerchantCertificates(t *testing.T) {
 	})
 }
 
+func TestGooglePayMerchantCertificates_ImportRejectsMalformedId(t *testing.T) {
+	for _, id := range []string{"certificate-id", "/certificate-id", "registration-id/", "registration-id/certificate-id/extra"} {
+		data := resourceBasisTheoryGooglePayMerchantCertificates().Data(nil)
+		data.SetId(id)
+
+		_, err := resourceGooglePayMerchantCertificatesImport(context.Background(), data, nil)
+
+		if err == nil || !strings.Contains(err.Error(), "expected <merchant_registration_id>/<certificate_id>") {
+			t.Fatalf("expected malformed ID %q to be rejected, got %v", id, err)
+		}
+	}
+}
+
+func TestGooglePayMerchantCertificates_ImportSetsMerchantRegistrationId(t *testing.T) {
+	data := resourceBasisTheoryGooglePayMerchantCertificates().Data(nil)
+	data.SetId("registration-id/certificate-id")
+
+	imported, err := resourceGooglePayMerchantCertificatesImport(context.Background(), data, nil)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if imported[0].Id() != "certificate-id" {
+		t.Fatalf("expected id to be certificate-id, got %s", imported[0].Id())
+	}
+	if actual := imported[0].Get("merchant_registration_id"); actual != "registration-id" {
+		t.Fatalf("expected merchant_registration_id to be registration-id, got %s", actual)
+	}
+}
+
+func TestGooglePayMerchantCertificates_ImportedWriteOnlyInputsDoNotForceReplacement(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "certificate-id",
+		Attributes: map[string]string{
+			"id":                       "certificate-id",
+			"merchant_registration_id": "registration-id",
+		},
+	}
+
+	diff, err := testGooglePayMerchantCertificatesPlan(state)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && diff.RequiresNew() {
+		t.Fatalf("expected imported certificate not to be replaced, got %#v", diff.Attributes)
+	}
+}
+
+func TestGooglePayMerchantCertificates_ChangedWriteOnlyInputsForceReplacement(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "certificate-id",
+		Attributes: map[string]string{
+			"id":                            "certificate-id",
+			"merchant_registration_id":      "registration-id",
+			"merchant_certificate_data":     "old-certificate",
+			"merchant_certificate_password": "password",
+		},
+	}
+
+	diff, err := testGooglePayMerchantCertificatesPlan(state)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || !diff.RequiresNew() {
+		t.Fatalf("expected changed certificate data to force replacement")
+	}
+}
+
+func testGooglePayMerchantCertificatesPlan(state *terraform.InstanceState) (*terraform.InstanceDiff, error) {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"merchant_registration_id":      "registration-id",
+		"merchant_certificate_data":     "new-certificate",
+		"merchant_certificate_password": "password",
+	})
+
+	return resourceBasisTheoryGooglePayMerchantCertificates().Diff(context.Background(), state, config, nil)
+}
+
+func testAccGooglePayMerchantCertificatesImportStateId(resourceName string) resource.ImportStateIdFunc {
+	return func(s *terraform.State) (string, error) {
+		rs, ok := s.RootModule().Resources[resourceName]
+		if !ok {
+			return "", fmt.Errorf("resource not found: %s", resourceName)
+		}
+
+		return fmt.Sprintf("%s/%s", rs.Primary.Attributes["merchant_registration_id"], rs.Primary.ID), nil
+	}
+}
+
 func testAccGooglePayMerchantCertificatesConfig(merchantIdentifier string) string {
 	return fmt.Sprintf(`
 resource "basistheory_google_pay_merchant_registration" "terraform_test_google_pay_merchant" {
@@ -66,10 +163,7 @@ resource "basistheory_google_pay_merchant_certificates" "terraform_test_google_p
 }
 
 func testAccCheckGooglePayMerchantCertificatesDestroy(s *terraform.State) error {
-	btClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	btClient := newTestAPIClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "basistheory_google_pay_merchant_certificates" {
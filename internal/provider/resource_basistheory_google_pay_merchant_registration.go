@@ -15,6 +15,10 @@ func resourceBasisTheoryGooglePayMerchantRegistration() *schema.Resource {
 	return &schema.Resource{
 		Description: "Google Pay Merchant Registration https://developers.basistheory.com/docs/api/google-pay/merchant-registration",
 
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
 		CreateContext: resourceGooglePayMerchantRegistrationCreate,
 		ReadContext:   resourceGooglePayMerchantRegistrationRead,
 		DeleteContext: resourceGooglePayMerchantRegistrationDelete,
@@ -54,7 +58,7 @@ func resourceGooglePayMerchantRegistrationCreate(ctx context.Context, data *sche
 		MerchantIdentifier: &merchantIdentifier,
 	})
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Google Pay Merchant Registration:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Google Pay Merchant Registration:", err, resourceBasisTheoryGooglePayMerchantRegistration().Schema)
 	}
 
 	data.SetId(*merchant.ID)
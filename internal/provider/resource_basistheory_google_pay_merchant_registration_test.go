@@ -4,13 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"os"
 	"regexp"
 	"testing"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
@@ -34,15 +31,17 @@ func TestGooglePayMerchantRegistration(t *testing.T) {
 						"basistheory_google_pay_merchant_registration.terraform_test_google_pay_merchant", "created_at"),
 				),
 			},
+			{
+				ResourceName:      "basistheory_google_pay_merchant_registration.terraform_test_google_pay_merchant",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
 
 func testAccCheckGooglePayMerchantRegistrationDestroy(s *terraform.State) error {
-	btClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	btClient := newTestAPIClient()
 
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "basistheory_google_pay_merchant_registration" {
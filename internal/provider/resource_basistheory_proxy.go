@@ -5,13 +5,16 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
 	"github.com/Basis-Theory/go-sdk/v7/option"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,12 +26,21 @@ func resourceBasisTheoryProxy() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		CreateContext: resourceProxyCreate,
-		ReadContext:   resourceProxyRead,
-		UpdateContext: resourceProxyUpdate,
-		DeleteContext: resourceProxyDelete,
+		CreateWithoutTimeout: warnUnknownPermissions(resourceProxyCreate, proxyPermissionsFromData),
+		ReadContext:          resourceProxyRead,
+		UpdateWithoutTimeout: warnUnknownPermissions(resourceProxyUpdate, proxyPermissionsFromData),
+		DeleteWithoutTimeout: resourceProxyDelete,
 
-		SchemaVersion: 1, // Increment schema version for the migration
+		CustomizeDiff: customdiff.All(
+			resourceProxyCustomizeDiff,
+			resourceProxyCodeFileCustomizeDiff,
+			resourceProxyPermissionsCustomizeDiff,
+			sensitiveConfigurationCustomizeDiff,
+		),
+
+		Timeouts: provisioningResourceTimeouts(),
+
+		SchemaVersion: 1,
 		StateUpgraders: []schema.StateUpgrader{
 			{
 				Type:    resourceBasisTheoryProxyResourceV0().CoreConfigSchema().ImpliedType(),
@@ -72,6 +84,7 @@ func resourceBasisTheoryProxy() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			sensitiveConfigurationKey: sensitiveConfigurationSchema("Proxy"),
 			"application_id": {
 				Description: "The Application's API key used in the BasisTheory instance passed into the Proxy Transform",
 				Type:        schema.TypeString,
@@ -89,6 +102,15 @@ func resourceBasisTheoryProxy() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"requested": provisioningRequestedSchema("Proxy"),
+			"code_sha256": {
+				Description: "SHA-256 hash of the code of the code transforms loaded from `code_file`, keyed by `request_transforms` or `response_transforms`",
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
 			"disable_detokenization": {
 				Description: "When true, disables all detokenization processing and passes detokenization expressions through as literal text",
 				Type:        schema.TypeBool,
@@ -115,6 +137,9 @@ func resourceBasisTheoryProxy() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			deletionProtectionKey: deletionProtectionSchema("Proxy"),
+			waitForActiveKey:      waitForActiveSchema("Proxy"),
+			acceptStatesKey:       acceptStatesSchema("Proxy"),
 			"encrypted": {
 				Description: "Base64-encoded encrypted token request data",
 				Type:        schema.TypeString,
@@ -129,6 +154,7 @@ func resourceBasisTheoryProxy() *schema.Resource {
 					Schema: map[string]*schema.Schema{
 						"type":        {Type: schema.TypeString, Optional: true},
 						"code":        {Type: schema.TypeString, Optional: true},
+						"code_file":   proxyTransformCodeFileSchema(),
 						"matcher":     {Type: schema.TypeString, Optional: true},
 						"expression":  {Type: schema.TypeString, Optional: true},
 						"replacement": {Type: schema.TypeString, Optional: true},
@@ -157,10 +183,12 @@ func resourceBasisTheoryProxy() *schema.Resource {
 										Optional:    true,
 									},
 									"token": {
-										Description: "Token configuration for tokenize transforms (JSON string)",
-										Type:        schema.TypeString,
-										Optional:    true,
+										Description:      "Token configuration for tokenize transforms (JSON string)",
+										Type:             schema.TypeString,
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentJSONDiffs,
 									},
+									"token_template": proxyTokenTemplateSchema(),
 									// New runtime block
 									"runtime": {
 										Description: "Runtime configuration for code transforms",
@@ -181,13 +209,6 @@ func resourceBasisTheoryProxy() *schema.Resource {
 									},
 								},
 							},
-							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-								// For token field, suppress diff if JSON content is equivalent
-								if strings.HasSuffix(k, ".token") {
-									return jsonEqual(old, new)
-								}
-								return old == new
-							},
 						},
 					},
 				},
@@ -206,6 +227,7 @@ func resourceBasisTheoryProxy() *schema.Resource {
 							Type:     schema.TypeString,
 							Optional: true,
 						},
+						"code_file": proxyTransformCodeFileSchema(),
 						"matcher": {
 							Type:     schema.TypeString,
 							Optional: true,
@@ -243,10 +265,12 @@ func resourceBasisTheoryProxy() *schema.Resource {
 										Optional:    true,
 									},
 									"token": {
-										Description: "Token configuration for tokenize transforms (JSON string)",
-										Type:        schema.TypeString,
-										Optional:    true,
+										Description:      "Token configuration for tokenize transforms (JSON string)",
+										Type:             schema.TypeString,
+										Optional:         true,
+										DiffSuppressFunc: suppressEquivalentJSONDiffs,
 									},
+									"token_template": proxyTokenTemplateSchema(),
 									// New runtime block
 									"runtime": {
 										Description: "Runtime configuration for code transforms",
@@ -267,13 +291,6 @@ func resourceBasisTheoryProxy() *schema.Resource {
 									},
 								},
 							},
-							DiffSuppressFunc: func(k, old, new string, d *schema.ResourceData) bool {
-								// For token field, suppress diff if JSON content is equivalent
-								if strings.HasSuffix(k, ".token") {
-									return jsonEqual(old, new)
-								}
-								return old == new
-							},
 						},
 					},
 				},
@@ -429,6 +446,9 @@ func resourceBasisTheoryProxyStateUpgradeV0(ctx context.Context, rawState map[st
 }
 
 func resourceProxyCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx, cancel := withProvisioningTimeout(ctx, data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
 	// Validate transforms
@@ -438,9 +458,10 @@ func resourceProxyCreate(ctx context.Context, data *schema.ResourceData, meta in
 			var diagErrors diag.Diagnostics
 			for _, err := range errs {
 				diagErrors = append(diagErrors, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Invalid request_transforms configuration",
-					Detail:   err.Error(),
+					Severity:      diag.Error,
+					Summary:       "Invalid request_transforms configuration",
+					Detail:        err.Error(),
+					AttributePath: errorAttributePath(err),
 				})
 			}
 			return diagErrors
@@ -453,16 +474,20 @@ func resourceProxyCreate(ctx context.Context, data *schema.ResourceData, meta in
 			var diagErrors diag.Diagnostics
 			for _, err := range errs {
 				diagErrors = append(diagErrors, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Invalid response_transforms configuration",
-					Detail:   err.Error(),
+					Severity:      diag.Error,
+					Summary:       "Invalid response_transforms configuration",
+					Detail:        err.Error(),
+					AttributePath: errorAttributePath(err),
 				})
 			}
 			return diagErrors
 		}
 	}
 
-	proxy := getProxyFromData(data)
+	proxy, err := getProxyFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	proxyRequest := &basistheory.CreateProxyRequest{
 		Name:           getStringValue(proxy.Name),
@@ -495,7 +520,6 @@ func resourceProxyCreate(ctx context.Context, data *schema.ResourceData, meta in
 	}
 
 	var createdProxy *basistheory.Proxy
-	var err error
 
 	if len(requestOptions) > 0 {
 		createdProxy, err = basisTheoryClient.Proxies.Create(ctx, proxyRequest, requestOptions...)
@@ -504,34 +528,37 @@ func resourceProxyCreate(ctx context.Context, data *schema.ResourceData, meta in
 	}
 
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Proxy:", err)
+		return remapAttributePaths(apiErrorDiagnosticsWithAttributePaths("Error creating Proxy:", err, resourceBasisTheoryProxy().Schema), proxyAttributePath(data))
 	}
 
 	data.SetId(*createdProxy.ID)
 
+	if !data.Get(waitForActiveKey).(bool) {
+		return setProxyState(data, createdProxy)
+	}
+
 	// Wait for the proxy to reach a final state, then set state from that same
 	// confirmed-active read (avoids a second independent GET that can race the
 	// write against a non-read-your-writes API).
-	finalProxy, diags := waitForProxyFinalState(ctx, basisTheoryClient, data.Id())
-	if diags != nil {
+	finalProxy, diags := waitForProxyFinalState(ctx, basisTheoryClient, data.Id(), provisioningAcceptedStates(data))
+	if diags.HasError() {
 		return diags
 	}
 
-	return setProxyState(data, finalProxy)
+	return append(diags, setProxyState(data, finalProxy)...)
 }
 
-func waitForProxyFinalState(ctx context.Context, client *basistheoryClient.Client, id string) (*basistheory.Proxy, diag.Diagnostics) {
-	// Poll every 2 seconds up to 10 minutes
-	interval := 2 * time.Second
-	deadline := time.Now().Add(10 * time.Minute)
-
-	for {
-		if time.Now().After(deadline) {
-			return nil, diag.Errorf("timeout waiting for proxy %s to reach a final state", id)
-		}
-
+// waitForProxyFinalState polls until the proxy settles. Accepted states other
+// than active are returned as warnings along with the proxy.
+func waitForProxyFinalState(ctx context.Context, client *basistheoryClient.Client, id string, acceptedStates []string) (*basistheory.Proxy, diag.Diagnostics) {
+	// Poll with exponential backoff until ctx, bounded by the resource's
+	// timeouts, expires
+	for attempt := 0; ; attempt++ {
 		proxy, err := client.Proxies.Get(ctx, id)
 		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, diag.Errorf("timeout waiting for proxy %s to reach a final state", id)
+			}
 			return nil, apiErrorDiagnostics("Error polling Proxy:", err)
 		}
 
@@ -544,13 +571,19 @@ func waitForProxyFinalState(ctx context.Context, client *basistheoryClient.Clien
 		case "active":
 			return proxy, nil
 		case "failed", "outdated":
+			if containsString(acceptedStates, state) {
+				return proxy, provisioningWarnings(proxyFinalStateDiagnostics(id, state, proxy))
+			}
 			return nil, proxyFinalStateDiagnostics(id, state, proxy)
 		}
 
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return nil, diag.Errorf("timeout waiting for proxy %s to reach a final state", id)
+			}
 			return nil, diag.FromErr(ctx.Err())
-		case <-time.After(interval):
+		case <-time.After(provisioningPollInterval(attempt)):
 		}
 	}
 }
@@ -582,6 +615,14 @@ func proxyFinalStateDiagnostics(id string, state string, proxy *basistheory.Prox
 	return diag.Errorf(message, errorArgs...)
 }
 
+func flattenProxyRequested(requested *basistheory.RequestedProxy) []interface{} {
+	if requested == nil {
+		return []interface{}{}
+	}
+
+	return flattenProvisioningRequested(requested.GetState(), requested.GetErrorCode(), requested.GetErrorMessage(), requested.GetErrorDetails())
+}
+
 func formatRequestedErrorDetails(errorDetails map[string]interface{}) string {
 	formattedDetails, err := json.MarshalIndent(errorDetails, "\t\t", "  ")
 	if err != nil {
@@ -617,10 +658,35 @@ func resourceProxyRead(ctx context.Context, data *schema.ResourceData, meta inte
 // waitForProxyFinalState) rather than doing a separate follow-up GET: the API is
 // not guaranteed read-your-writes consistent across pods, so an immediate
 // independent read can return a pre-write / still-"creating" snapshot and cause
-// Terraform to persist stale values.
+// Terraform to persist stale values. Only the resource uses it, since it reads
+// local-only attributes from the resource configuration; the data sources use
+// setProxyDataSourceState.
 func setProxyState(data *schema.ResourceData, proxy *basistheory.Proxy) diag.Diagnostics {
 	data.SetId(*proxy.ID)
 
+	proxyData := flattenProxy(proxy)
+	codeHashes := map[string]interface{}{}
+	for _, fieldName := range []string{"request_transforms", "response_transforms"} {
+		useProxyTokenTemplates(data, fieldName, proxyData[fieldName].([]map[string]interface{}))
+		useProxyCodeFiles(data, fieldName, proxyData[fieldName].([]map[string]interface{}), codeHashes)
+	}
+	proxyData["code_sha256"] = codeHashes
+	proxyData["configuration"], proxyData[sensitiveConfigurationKey] = splitConfiguration(data, proxy.Configuration)
+	// Keeps the defaults in state for imported Proxies
+	for _, key := range provisioningLocalKeys {
+		proxyData[key] = data.Get(key)
+	}
+
+	for proxyDatumName, proxyDatum := range proxyData {
+		if err := data.Set(proxyDatumName, proxyDatum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func flattenProxy(proxy *basistheory.Proxy) map[string]interface{} {
 	modifiedAt := ""
 	if proxy.ModifiedAt != nil {
 		modifiedAt = proxy.ModifiedAt.String()
@@ -631,8 +697,8 @@ func setProxyState(data *schema.ResourceData, proxy *basistheory.Proxy) diag.Dia
 		createdAt = proxy.CreatedAt.String()
 	}
 
-	// Set basic attributes
-	basicAttributes := map[string]interface{}{
+	return map[string]interface{}{
+		"id":                     proxy.ID,
 		"key":                    proxy.Key,
 		"tenant_id":              proxy.TenantID,
 		"name":                   proxy.Name,
@@ -642,31 +708,26 @@ func setProxyState(data *schema.ResourceData, proxy *basistheory.Proxy) diag.Dia
 		"require_auth":           proxy.RequireAuth,
 		"disable_detokenization": proxy.DisableDetokenization,
 		"state":                  proxy.State,
+		"requested":              flattenProxyRequested(proxy.GetRequested()),
 		"created_at":             createdAt,
 		"created_by":             proxy.CreatedBy,
 		"modified_at":            modifiedAt,
 		"modified_by":            proxy.ModifiedBy,
+		"request_transforms":     flattenProxyTransforms(proxy.RequestTransforms),
+		"response_transforms":    flattenProxyTransforms(proxy.ResponseTransforms),
 	}
+}
 
-	for proxyDatumName, proxyDatum := range basicAttributes {
-		if err := data.Set(proxyDatumName, proxyDatum); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	// Handle transforms
-	if err := data.Set("request_transforms", flattenProxyTransforms(proxy.RequestTransforms)); err != nil {
-		return diag.FromErr(err)
-	}
-
-	if err := data.Set("response_transforms", flattenProxyTransforms(proxy.ResponseTransforms)); err != nil {
-		return diag.FromErr(err)
+func resourceProxyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// deletion_protection and the provisioning wait settings only live in
+	// Terraform state
+	if !data.HasChangesExcept(provisioningLocalKeys...) {
+		return nil
 	}
 
-	return nil
-}
+	ctx, cancel := withProvisioningTimeout(ctx, data, meta, schema.TimeoutUpdate)
+	defer cancel()
 
-func resourceProxyUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
 	// Validate transforms
@@ -676,9 +737,10 @@ func resourceProxyUpdate(ctx context.Context, data *schema.ResourceData, meta in
 			var diagErrors diag.Diagnostics
 			for _, err := range errs {
 				diagErrors = append(diagErrors, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Invalid request_transforms configuration",
-					Detail:   err.Error(),
+					Severity:      diag.Error,
+					Summary:       "Invalid request_transforms configuration",
+					Detail:        err.Error(),
+					AttributePath: errorAttributePath(err),
 				})
 			}
 			return diagErrors
@@ -691,16 +753,20 @@ func resourceProxyUpdate(ctx context.Context, data *schema.ResourceData, meta in
 			var diagErrors diag.Diagnostics
 			for _, err := range errs {
 				diagErrors = append(diagErrors, diag.Diagnostic{
-					Severity: diag.Error,
-					Summary:  "Invalid response_transforms configuration",
-					Detail:   err.Error(),
+					Severity:      diag.Error,
+					Summary:       "Invalid response_transforms configuration",
+					Detail:        err.Error(),
+					AttributePath: errorAttributePath(err),
 				})
 			}
 			return diagErrors
 		}
 	}
 
-	proxy := getProxyFromData(data)
+	proxy, err := getProxyFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 	updateProxyRequest := &basistheory.UpdateProxyRequest{
 		Name:           getStringValue(proxy.Name),
 		DestinationURL: getStringValue(proxy.DestinationURL),
@@ -723,27 +789,43 @@ func resourceProxyUpdate(ctx context.Context, data *schema.ResourceData, meta in
 	updatedProxy, err := basisTheoryClient.Proxies.Update(ctx, getStringValue(proxy.ID), updateProxyRequest)
 
 	if err != nil {
-		return apiErrorDiagnostics("Error updating Proxy:", err)
+		return remapAttributePaths(apiErrorDiagnosticsWithAttributePaths("Error updating Proxy:", err, resourceBasisTheoryProxy().Schema), proxyAttributePath(data))
+	}
+
+	if !data.Get(waitForActiveKey).(bool) {
+		return setProxyState(data, updatedProxy)
 	}
 
 	// Wait for provisioning to settle before returning.
-	finalProxy, diags := waitForProxyFinalState(ctx, basisTheoryClient, data.Id())
-	if diags != nil {
+	finalProxy, diags := waitForProxyFinalState(ctx, basisTheoryClient, data.Id(), provisioningAcceptedStates(data))
+	if diags.HasError() {
 		return diags
 	}
 
 	// Populate state from the authoritative update response rather than a
 	// follow-up GET: the API is not read-your-writes consistent across pods, so
 	// an immediate independent read can return the pre-update snapshot. Reflect
-	// the settled lifecycle state from the wait's confirmed-active read.
-	if diags := setProxyState(data, updatedProxy); diags != nil {
-		return diags
+	// the settled lifecycle state and provisioning outcome from the wait's
+	// confirmed-active read.
+	if stateDiags := setProxyState(data, updatedProxy); stateDiags != nil {
+		return append(diags, stateDiags...)
+	}
+
+	if err := data.Set("state", finalProxy.State); err != nil {
+		return append(diags, diag.FromErr(err)...)
 	}
 
-	return diag.FromErr(data.Set("state", finalProxy.State))
+	return append(diags, diag.FromErr(data.Set("requested", flattenProxyRequested(finalProxy.GetRequested())))...)
 }
 
 func resourceProxyDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := deletionProtectionDiagnostics(data, "Proxy"); diags != nil {
+		return diags
+	}
+
+	ctx, cancel := withProvisioningTimeout(ctx, data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
 	err := basisTheoryClient.Proxies.Delete(ctx, data.Id())
@@ -761,7 +843,7 @@ func resourceProxyDelete(ctx context.Context, data *schema.ResourceData, meta in
 	return nil
 }
 
-func getProxyFromData(data *schema.ResourceData) basistheory.Proxy {
+func getProxyFromData(data *schema.ResourceData) (basistheory.Proxy, error) {
 	id := data.Id()
 	proxy := basistheory.Proxy{
 		ID:                    &id,
@@ -772,37 +854,36 @@ func getProxyFromData(data *schema.ResourceData) basistheory.Proxy {
 		DisableDetokenization: getBoolPointer(data.Get("disable_detokenization")),
 	}
 
+	var err error
+
 	// Handle request_transforms array
-	proxy.RequestTransforms = parseTransformsFromData(data, "request_transforms")
+	if proxy.RequestTransforms, err = parseTransformsFromData(data, "request_transforms"); err != nil {
+		return proxy, err
+	}
 
 	// Handle response_transforms array
-	proxy.ResponseTransforms = parseTransformsFromData(data, "response_transforms")
-
-	configOptions := map[string]*string{}
-	if cfg, ok := data.GetOk("configuration"); ok {
-		for key, value := range cfg.(map[string]interface{}) {
-			configOptions[key] = getStringPointer(value)
-		}
+	if proxy.ResponseTransforms, err = parseTransformsFromData(data, "response_transforms"); err != nil {
+		return proxy, err
 	}
 
-	proxy.Configuration = configOptions
+	proxy.Configuration = configurationFromData(data)
 
-	return proxy
+	return proxy, nil
 }
 
-func parseTransformsFromData(data *schema.ResourceData, fieldName string) []*basistheory.ProxyTransform {
+func parseTransformsFromData(data *schema.ResourceData, fieldName string) ([]*basistheory.ProxyTransform, error) {
 	transformsRaw, ok := data.GetOk(fieldName)
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
 	transformsList, ok := transformsRaw.([]interface{})
 	if !ok {
-		return nil
+		return nil, nil
 	}
 
 	var transforms []*basistheory.ProxyTransform
-	for _, item := range transformsList {
+	for i, item := range transformsList {
 		if transformMap, ok := item.(map[string]interface{}); ok {
 			transform := &basistheory.ProxyTransform{}
 
@@ -813,6 +894,13 @@ func parseTransformsFromData(data *schema.ResourceData, fieldName string) []*bas
 			if val, exists := transformMap["code"]; exists && val != nil {
 				transform.Code = getStringPointer(val)
 			}
+			if codeFile, _ := transformMap["code_file"].(string); codeFile != "" {
+				code, err := readCodeFile(codeFile)
+				if err != nil {
+					return nil, cty.GetAttrPath(fieldName).IndexInt(i).GetAttr("code_file").NewError(err)
+				}
+				transform.Code = &code
+			}
 			if val, exists := transformMap["matcher"]; exists && !IsNilOrEmpty(val) {
 				transform.Matcher = getStringPointer(val)
 			}
@@ -859,6 +947,13 @@ func parseTransformsFromData(data *schema.ResourceData, fieldName string) []*bas
 							}
 						}
 
+						if tokenTemplate, exists := optionsMap["token_template"]; exists && tokenTemplate != nil {
+							if tokenTemplateList, ok := tokenTemplate.([]interface{}); ok && len(tokenTemplateList) > 0 && tokenTemplateList[0] != nil {
+								options.Token = expandProxyTokenTemplate(tokenTemplateList[0].(map[string]interface{}))
+								hasOptionsData = true
+							}
+						}
+
 						// Handle runtime (now under options)
 						if rtRaw, exists := optionsMap["runtime"]; exists && rtRaw != nil {
 							if rtList, ok := rtRaw.([]interface{}); ok && len(rtList) > 0 {
@@ -947,7 +1042,7 @@ func parseTransformsFromData(data *schema.ResourceData, fieldName string) []*bas
 		}
 	}
 
-	return transforms
+	return transforms, nil
 }
 
 func flattenProxyTransforms(transforms []*basistheory.ProxyTransform) []map[string]interface{} {
@@ -1068,6 +1163,258 @@ func flattenProxyTransforms(transforms []*basistheory.ProxyTransform) []map[stri
 	return result
 }
 
+func proxyTokenTemplateSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Structured token configuration for tokenize transforms. Conflicts with `token`. Switching an existing transform from `token` shows a one-time in-place update that moves the value into this block",
+		Type:        schema.TypeList,
+		Optional:    true,
+		MaxItems:    1,
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"type": {
+					Description: "Type of the Token to create",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"data": {
+					Description:      "Data expression for the Token. Either a string or a JSON object",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: suppressEquivalentJSONDiffs,
+				},
+				"containers": {
+					Description: "Containers to place the Token in",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"metadata": {
+					Description: "Non-sensitive metadata to store with the Token",
+					Type:        schema.TypeMap,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"search_indexes": {
+					Description: "Expressions used to index the Token for search",
+					Type:        schema.TypeList,
+					Optional:    true,
+					Elem:        &schema.Schema{Type: schema.TypeString},
+				},
+				"fingerprint_expression": {
+					Description: "Expression used to fingerprint the Token",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+				"mask": {
+					Description:      "Mask expression for the Token. Either a string or a JSON object",
+					Type:             schema.TypeString,
+					Optional:         true,
+					DiffSuppressFunc: suppressEquivalentJSONDiffs,
+				},
+				"deduplicate_token": {
+					Description: "Whether to return an existing Token with the same fingerprint instead of creating a new one",
+					Type:        schema.TypeBool,
+					Optional:    true,
+				},
+				"expires_at": {
+					Description: "Timestamp at which the Token expires",
+					Type:        schema.TypeString,
+					Optional:    true,
+				},
+			},
+		},
+	}
+}
+
+func expandProxyTokenTemplate(tokenTemplate map[string]interface{}) *basistheory.CreateTokenRequest {
+	token := &basistheory.CreateTokenRequest{}
+
+	if val, ok := tokenTemplate["type"].(string); ok && val != "" {
+		token.Type = getStringPointer(val)
+	}
+	if val, ok := tokenTemplate["data"].(string); ok && val != "" {
+		token.Data = expandJSONOrString(val)
+	}
+	if val, ok := tokenTemplate["containers"].([]interface{}); ok && len(val) > 0 {
+		for _, container := range val {
+			token.Containers = append(token.Containers, container.(string))
+		}
+	}
+	if val, ok := tokenTemplate["metadata"].(map[string]interface{}); ok && len(val) > 0 {
+		token.Metadata = map[string]*string{}
+		for key, value := range val {
+			token.Metadata[key] = getStringPointer(value)
+		}
+	}
+	if val, ok := tokenTemplate["search_indexes"].([]interface{}); ok && len(val) > 0 {
+		for _, searchIndex := range val {
+			token.SearchIndexes = append(token.SearchIndexes, searchIndex.(string))
+		}
+	}
+	if val, ok := tokenTemplate["fingerprint_expression"].(string); ok && val != "" {
+		token.FingerprintExpression = getStringPointer(val)
+	}
+	if val, ok := tokenTemplate["mask"].(string); ok && val != "" {
+		token.Mask = expandJSONOrString(val)
+	}
+	if val, ok := tokenTemplate["deduplicate_token"].(bool); ok && val {
+		token.DeduplicateToken = getBoolPointer(val)
+	}
+	if val, ok := tokenTemplate["expires_at"].(string); ok && val != "" {
+		token.ExpiresAt = getStringPointer(val)
+	}
+
+	return token
+}
+
+func flattenProxyTokenTemplate(token *basistheory.CreateTokenRequest) []interface{} {
+	metadata := map[string]string{}
+	for key, value := range token.Metadata {
+		if value != nil {
+			metadata[key] = *value
+		}
+	}
+
+	deduplicateToken := false
+	if token.DeduplicateToken != nil {
+		deduplicateToken = *token.DeduplicateToken
+	}
+
+	return []interface{}{map[string]interface{}{
+		"type":                   getStringValue(token.Type),
+		"data":                   flattenJSONOrString(token.Data),
+		"containers":             token.Containers,
+		"metadata":               metadata,
+		"search_indexes":         token.SearchIndexes,
+		"fingerprint_expression": getStringValue(token.FingerprintExpression),
+		"mask":                   flattenJSONOrString(token.Mask),
+		"deduplicate_token":      deduplicateToken,
+		"expires_at":             getStringValue(token.ExpiresAt),
+	}}
+}
+
+// useProxyTokenTemplates keeps the token of each flattened transform in the
+// form the configuration uses: transforms that declare a token_template block
+// get the API token flattened into it instead of the legacy token string.
+func useProxyTokenTemplates(data *schema.ResourceData, fieldName string, transforms []map[string]interface{}) {
+	for i, transform := range transforms {
+		if data.Get(fmt.Sprintf("%s.%d.options.0.token_template.#", fieldName, i)).(int) == 0 {
+			continue
+		}
+
+		options, ok := transform["options"].([]interface{})
+		if !ok || len(options) == 0 {
+			continue
+		}
+
+		optionsMap := options[0].(map[string]interface{})
+		tokenJSON, ok := optionsMap["token"].(string)
+		if !ok {
+			continue
+		}
+
+		var token basistheory.CreateTokenRequest
+		if err := json.Unmarshal([]byte(tokenJSON), &token); err != nil {
+			continue
+		}
+
+		optionsMap["token_template"] = flattenProxyTokenTemplate(&token)
+		delete(optionsMap, "token")
+	}
+}
+
+// useProxyCodeFiles keeps code_file in state for transforms configured from a
+// file, recording the hash of their code instead of the code itself. code_file
+// only exists on the resource, so the data sources never call it.
+func useProxyCodeFiles(data *schema.ResourceData, fieldName string, transforms []map[string]interface{}, codeHashes map[string]interface{}) {
+	for i, transform := range transforms {
+		codeFile := data.Get(fmt.Sprintf("%s.%d.code_file", fieldName, i)).(string)
+		if codeFile == "" {
+			continue
+		}
+
+		code, _ := transform["code"].(string)
+		codeHashes[fieldName] = codeSha256(code)
+		transform["code_file"] = codeFile
+		delete(transform, "code")
+	}
+}
+
+func proxyTransformCodeFileSchema() *schema.Schema {
+	return &schema.Schema{
+		Description: "Path to a file holding the code of a code transform. Conflicts with `code`; only its hash is kept in state",
+		Type:        schema.TypeString,
+		Optional:    true,
+	}
+}
+
+// resourceProxyCodeFileCustomizeDiff hashes the files referenced by code
+// transforms at plan time, so a change to their contents shows up as a
+// code_sha256 change.
+func resourceProxyCodeFileCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	codeHashes := map[string]interface{}{}
+
+	for _, fieldName := range []string{"request_transforms", "response_transforms"} {
+		if !diff.NewValueKnown(fieldName) {
+			return diff.SetNewComputed("code_sha256")
+		}
+
+		transforms, _ := diff.Get(fieldName).([]interface{})
+		for i := range transforms {
+			key := fmt.Sprintf("%s.%d.code_file", fieldName, i)
+			if !diff.NewValueKnown(key) {
+				return diff.SetNewComputed("code_sha256")
+			}
+
+			codeFile := diff.Get(key).(string)
+			if codeFile == "" {
+				continue
+			}
+
+			code, err := readCodeFile(codeFile)
+			if err != nil {
+				return cty.GetAttrPath(fieldName).IndexInt(i).GetAttr("code_file").NewError(err)
+			}
+			codeHashes[fieldName] = codeSha256(code)
+		}
+	}
+
+	if reflect.DeepEqual(diff.Get("code_sha256"), codeHashes) {
+		return nil
+	}
+
+	return diff.SetNew("code_sha256", codeHashes)
+}
+
+// expandJSONOrString sends JSON objects and arrays as structured values and
+// anything else, such as detokenization expressions, as a plain string.
+func expandJSONOrString(value string) interface{} {
+	trimmed := strings.TrimSpace(value)
+	if strings.HasPrefix(trimmed, "{") || strings.HasPrefix(trimmed, "[") {
+		var decoded interface{}
+		if err := json.Unmarshal([]byte(trimmed), &decoded); err == nil {
+			return decoded
+		}
+	}
+
+	return value
+}
+
+func flattenJSONOrString(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return typed
+	default:
+		encoded, err := json.Marshal(typed)
+		if err != nil {
+			return ""
+		}
+		return string(encoded)
+	}
+}
+
 func validateRequestTransforms(val interface{}, _ string) (warns []string, errs []error) {
 	transforms, ok := val.([]interface{})
 	if !ok {
@@ -1088,26 +1435,157 @@ func validateResponseTransforms(val interface{}, _ string) (warns []string, errs
 	return validateProxyTransforms(transforms, "response_transforms")
 }
 
+// resourceProxyCustomizeDiff runs the transform validation at plan time so an
+// invalid transform fails the plan instead of a partially applied run.
+// Transforms holding values that are unknown until apply are only checked
+// against their siblings here; create and update validate them in full.
+func resourceProxyCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	var errs []error
+
+	for _, fieldName := range []string{"request_transforms", "response_transforms"} {
+		if !diff.NewValueKnown(fieldName) {
+			continue
+		}
+
+		transforms, ok := diff.Get(fieldName).([]interface{})
+		if !ok {
+			continue
+		}
+
+		_, transformErrs := validateKnownProxyTransforms(transforms, fieldName, func(index int) bool {
+			return proxyTransformKnown(diff, fmt.Sprintf("%s.%d", fieldName, index))
+		})
+		errs = append(errs, transformErrs...)
+	}
+
+	return joinPathErrors(errs)
+}
+
+// resourceProxyPermissionsCustomizeDiff checks at plan time that transform
+// runtimes are only granted permissions a private Application can hold.
+func resourceProxyPermissionsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	catalog, refreshed := permissionCatalogFromMeta(meta)
+
+	var errs []error
+	for _, fieldName := range []string{"request_transforms", "response_transforms"} {
+		transforms, _ := diff.Get(fieldName).([]interface{})
+		for index := range transforms {
+			key := fmt.Sprintf("%s.%d.options.0.runtime.0.permissions", fieldName, index)
+			if !diff.NewValueKnown(key) {
+				continue
+			}
+
+			permissions, _ := diff.Get(key).([]interface{})
+			path := cty.GetAttrPath(fieldName).IndexInt(index).GetAttr("options").IndexInt(0).GetAttr("runtime").IndexInt(0).GetAttr("permissions")
+			errs = append(errs, catalog.validatePermissions(runtimePermissionApplicationType, permissions, path, true, refreshed)...)
+		}
+	}
+
+	return joinPathErrors(errs)
+}
+
+// proxyPermissionsFromData returns the permissions granted to transform
+// runtimes.
+func proxyPermissionsFromData(data *schema.ResourceData) (string, []interface{}) {
+	var permissions []interface{}
+	for _, fieldName := range []string{"request_transforms", "response_transforms"} {
+		transforms, _ := data.Get(fieldName).([]interface{})
+		for index := range transforms {
+			transformPermissions, _ := data.Get(fmt.Sprintf("%s.%d.options.0.runtime.0.permissions", fieldName, index)).([]interface{})
+			permissions = append(permissions, transformPermissions...)
+		}
+	}
+
+	return runtimePermissionApplicationType, permissions
+}
+
+func proxyTransformKnown(diff *schema.ResourceDiff, key string) bool {
+	for _, attribute := range []string{"type", "code", "code_file", "matcher", "expression", "replacement", "options"} {
+		if !diff.NewValueKnown(key + "." + attribute) {
+			return false
+		}
+	}
+
+	options, _ := diff.Get(key + ".options").([]interface{})
+	for index := range options {
+		for _, attribute := range []string{"identifier", "value", "location", "token", "token_template"} {
+			if !diff.NewValueKnown(fmt.Sprintf("%s.options.%d.%s", key, index, attribute)) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// proxyAttributePath points API errors on a transform's token at its
+// token_template when that is how data sets it, since the API reports both as
+// the token option, and errors on configuration keys at sensitive_configuration
+// when they are set there.
+func proxyAttributePath(data *schema.ResourceData) func(cty.Path) cty.Path {
+	remapSensitiveConfiguration := sensitiveConfigurationAttributePath(data)
+
+	return func(path cty.Path) cty.Path {
+		if len(path) == 0 || attributePathKey(path[len(path)-1:]) != "token" {
+			return remapSensitiveConfiguration(path)
+		}
+
+		options := path[:len(path)-1]
+		if tokenTemplate, _ := data.Get(attributePathKey(options) + ".token_template").([]interface{}); len(tokenTemplate) == 0 {
+			return path
+		}
+
+		return options.Copy().GetAttr("token_template").IndexInt(0)
+	}
+}
+
+// errorAttributePath returns the attribute path attached to a transform
+// validation error, if any.
+func errorAttributePath(err error) cty.Path {
+	var pathError cty.PathError
+	if errors.As(err, &pathError) {
+		return pathError.Path
+	}
+
+	return nil
+}
+
 func validateProxyTransforms(transforms []interface{}, fieldName string) (warns []string, errs []error) {
+	return validateKnownProxyTransforms(transforms, fieldName, nil)
+}
+
+// validateKnownProxyTransforms validates the transforms for which isKnown
+// returns true (all of them when isKnown is nil) and checks identifiers and
+// code transforms across the whole list. Errors are cty.PathErrors pointing at
+// the offending transform, or at the list for errors spanning transforms.
+func validateKnownProxyTransforms(transforms []interface{}, fieldName string, isKnown func(index int) bool) (warns []string, errs []error) {
 	if len(transforms) == 0 {
 		return
 	}
 
+	fieldPath := cty.GetAttrPath(fieldName)
+
 	// Track identifiers for uniqueness validation
 	identifiers := make(map[string]bool)
 	codeTransformCount := 0
 
 	for i, transformRaw := range transforms {
+		transformPath := fieldPath.IndexInt(i)
+
 		transform, ok := transformRaw.(map[string]interface{})
 		if !ok {
-			errs = append(errs, fmt.Errorf("%s[%d]: expected transform object", fieldName, i))
+			errs = append(errs, transformPath.NewError(fmt.Errorf("%s[%d]: expected transform object", fieldName, i)))
 			continue
 		}
 
 		// Validate individual transform
-		transformWarns, transformErrs := validateProxyTransform(transform, fmt.Sprintf("%s[%d]", fieldName, i))
-		warns = append(warns, transformWarns...)
-		errs = append(errs, transformErrs...)
+		if isKnown == nil || isKnown(i) {
+			transformWarns, transformErrs := validateProxyTransform(transform, fmt.Sprintf("%s[%d]", fieldName, i))
+			warns = append(warns, transformWarns...)
+			for _, err := range transformErrs {
+				errs = append(errs, transformPath.NewError(err))
+			}
+		}
 
 		// Check for code transforms
 		if transformType, exists := transform["type"]; exists {
@@ -1136,7 +1614,7 @@ func validateProxyTransforms(transforms []interface{}, fieldName string) (warns
 					if identifierStr, ok := identifier.(string); ok && identifierStr != "" {
 						// Only check for duplicates if the identifier is non-empty
 						if identifiers[identifierStr] {
-							errs = append(errs, fmt.Errorf("duplicate identifier found in %s: %s", fieldName, identifierStr))
+							errs = append(errs, transformPath.NewError(fmt.Errorf("duplicate identifier found in %s: %s", fieldName, identifierStr)))
 						} else {
 							identifiers[identifierStr] = true
 						}
@@ -1149,7 +1627,7 @@ func validateProxyTransforms(transforms []interface{}, fieldName string) (warns
 
 	// Validate only one CODE transform is allowed
 	if codeTransformCount > 1 {
-		errs = append(errs, fmt.Errorf("only one CODE transform is allowed in %s", fieldName))
+		errs = append(errs, fieldPath.NewError(fmt.Errorf("only one CODE transform is allowed in %s", fieldName)))
 	}
 
 	return
@@ -1159,11 +1637,19 @@ func validateProxyTransform(transform map[string]interface{}, fieldName string)
 	// Basic type validation
 	if transformType, exists := transform["type"]; exists {
 		if typeStr, ok := transformType.(string); ok {
+			if codeFile, _ := transform["code_file"].(string); codeFile != "" && typeStr != "code" {
+				errs = append(errs, fmt.Errorf("%s: code_file is only valid when type is 'code'", fieldName))
+			}
+
 			switch typeStr {
 			case "code":
 				// Code validation
-				if code, exists := transform["code"]; !exists || code == nil || code.(string) == "" {
-					errs = append(errs, fmt.Errorf("%s: code is required when type is 'code'", fieldName))
+				code, _ := transform["code"].(string)
+				codeFile, _ := transform["code_file"].(string)
+				if code == "" && codeFile == "" {
+					errs = append(errs, fmt.Errorf("%s: code is required when type is 'code' (set code or code_file)", fieldName))
+				} else if code != "" && codeFile != "" {
+					errs = append(errs, fmt.Errorf("%s: only one of code or code_file can be set", fieldName))
 				}
 				// Code transforms should not have matcher, expression, or replacement
 				if matcher, exists := transform["matcher"]; exists && matcher != nil && matcher.(string) != "" {
@@ -1210,8 +1696,15 @@ func validateProxyTransform(transform map[string]interface{}, fieldName string)
 					}
 
 					if optionsMap != nil {
+						tokenTemplate, _ := optionsMap["token_template"].([]interface{})
+						hasTokenTemplate := len(tokenTemplate) > 0
+
 						if token, exists := optionsMap["token"]; !exists || token == nil || token.(string) == "" {
-							errs = append(errs, fmt.Errorf("%s: token is required in tokenize transform options", fieldName))
+							if !hasTokenTemplate {
+								errs = append(errs, fmt.Errorf("%s: token is required in tokenize transform options (set token or token_template)", fieldName))
+							}
+						} else if hasTokenTemplate {
+							errs = append(errs, fmt.Errorf("%s: only one of token or token_template can be set in tokenize transform options", fieldName))
 						} else if tokenStr, ok := token.(string); ok && tokenStr != "" {
 							// Validate that token is valid JSON
 							var js json.RawMessage
@@ -1281,7 +1774,14 @@ func IsNilOrEmpty(value interface{}) bool {
 	return false
 }
 
-// jsonEqual compares two JSON strings for semantic equality
+// suppressEquivalentJSONDiffs ignores differences in key order, whitespace and
+// fields the API fills in with empty defaults when comparing JSON attributes.
+func suppressEquivalentJSONDiffs(_, old, new string, _ *schema.ResourceData) bool {
+	return jsonEqual(old, new)
+}
+
+// jsonEqual compares two JSON strings for semantic equality. Null values and
+// empty objects or arrays inside objects are treated as absent.
 func jsonEqual(a, b string) bool {
 	if a == b {
 		return true
@@ -1297,14 +1797,50 @@ func jsonEqual(a, b string) bool {
 	}
 
 	// Marshal both back to normalized JSON for comparison
-	aBytes, err := json.Marshal(aObj)
+	aBytes, err := json.Marshal(normalizeJSONValue(aObj))
 	if err != nil {
 		return false
 	}
-	bBytes, err := json.Marshal(bObj)
+	bBytes, err := json.Marshal(normalizeJSONValue(bObj))
 	if err != nil {
 		return false
 	}
 
 	return string(aBytes) == string(bBytes)
 }
+
+func normalizeJSONValue(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		normalized := make(map[string]interface{}, len(typed))
+		for key, nested := range typed {
+			nested = normalizeJSONValue(nested)
+			if isEmptyJSONValue(nested) {
+				continue
+			}
+			normalized[key] = nested
+		}
+		return normalized
+	case []interface{}:
+		normalized := make([]interface{}, 0, len(typed))
+		for _, nested := range typed {
+			normalized = append(normalized, normalizeJSONValue(nested))
+		}
+		return normalized
+	default:
+		return value
+	}
+}
+
+func isEmptyJSONValue(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case map[string]interface{}:
+		return len(typed) == 0
+	case []interface{}:
+		return len(typed) == 0
+	default:
+		return false
+	}
+}
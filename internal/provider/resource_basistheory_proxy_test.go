@@ -5,14 +5,13 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"os"
+	"path/filepath"
 	"regexp"
 	"strings"
 	"testing"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -92,6 +91,30 @@ func TestProxyFinalStateDiagnostics_includesRequestedError(t *testing.T) {
 	}
 }
 
+func TestFlattenProxyRequested_includesRequestedError(t *testing.T) {
+	state := "failed"
+	errorMessage := "Unable to provision the Proxy"
+	proxy := &basistheory.Proxy{
+		Requested: &basistheory.RequestedProxy{
+			State:        &state,
+			ErrorMessage: &errorMessage,
+		},
+	}
+
+	actual := flattenProxy(proxy)["requested"].([]interface{})
+
+	if len(actual) != 1 {
+		t.Fatalf("expected one requested block, got %#v", actual)
+	}
+	requested := actual[0].(map[string]interface{})
+	if requested["state"] != "failed" || requested["error_code"] != "" || requested["error_message"] != errorMessage {
+		t.Fatalf("unexpected requested block %#v", requested)
+	}
+	if vulnerabilities := requested["vulnerabilities"].([]interface{}); len(vulnerabilities) != 0 {
+		t.Fatalf("expected no vulnerabilities, got %#v", vulnerabilities)
+	}
+}
+
 func TestGetProxyFromData_includesRuntimeResolutions(t *testing.T) {
 	data := schema.TestResourceDataRaw(t, resourceBasisTheoryProxy().Schema, map[string]interface{}{
 		"name":            "Terraform proxy with node 22 runtime",
@@ -119,7 +142,10 @@ func TestGetProxyFromData_includesRuntimeResolutions(t *testing.T) {
 		},
 	})
 
-	proxy := getProxyFromData(data)
+	proxy, err := getProxyFromData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
 
 	if len(proxy.RequestTransforms) != 1 {
 		t.Fatalf("expected one request transform, got %d", len(proxy.RequestTransforms))
@@ -255,6 +281,48 @@ func TestResourceProxyWithoutApplication(t *testing.T) {
 	})
 }
 
+func TestResourceProxy_ReportsOutdatedProvisioning(t *testing.T) {
+	fakeAPIOnly(t).setProvisioningOutcome("Terraform outdated proxy", "outdated")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "basistheory_proxy" "terraform_test_outdated_proxy" {
+  name            = "Terraform outdated proxy"
+  destination_url = "https://httpbin.org/post"
+}
+`,
+				ExpectError: regexp.MustCompile(`reached outdated state(.|\n)*Requested Proxy Error Code: provisioning_outdated`),
+			},
+		},
+	})
+}
+
+func TestResourceProxy_SkipsWaitingForActive(t *testing.T) {
+	fakeAPIOnly(t)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "basistheory_proxy" "terraform_test_unwaited_proxy" {
+  name            = "Terraform unwaited proxy"
+  destination_url = "https://httpbin.org/post"
+  wait_for_active = false
+}
+`,
+				Check: resource.TestCheckResourceAttr(
+					"basistheory_proxy.terraform_test_unwaited_proxy", "state", "creating"),
+			},
+		},
+	})
+}
+
 func TestResourceProxyWithoutRequireAuth(t *testing.T) {
 	skipForVaultApiCaching(t)
 	formattedTestAccReactorCreate := fmt.Sprintf(testAccReactorCreateWithoutApplication, "terraform_test_reactor_proxy_test")
@@ -685,6 +753,89 @@ func TestResourceProxyWithTokenizeRequestTransform(t *testing.T) {
 	})
 }
 
+func TestResourceProxyWithTokenTemplateRequestTransform(t *testing.T) {
+	skipForVaultApiCaching(t)
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckProxyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: buildProxyWithRequestTransformAttributes(`
+	type = "tokenize"
+	options {
+		identifier = "outputTokenA"
+		token_template {
+			type       = "card"
+			data       = "{{ encrypted | json: '$.data' }}"
+			containers = ["/pci/high/"]
+			metadata = {
+				foo = "bar"
+			}
+		}
+	}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token_template.0.type", "card"),
+					resource.TestCheckResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token_template.0.data", "{{ encrypted | json: '$.data' }}"),
+					resource.TestCheckResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token_template.0.containers.0", "/pci/high/"),
+					resource.TestCheckResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token_template.0.metadata.foo", "bar"),
+					resource.TestCheckNoResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token"),
+				),
+			},
+		},
+	})
+}
+
+func TestResourceProxySwitchingFromTokenToTokenTemplate(t *testing.T) {
+	skipForVaultApiCaching(t)
+	var createdID string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckProxyDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: buildProxyWithRequestTransformAttributes(`
+	type = "tokenize"
+	options {
+		identifier = "outputTokenA"
+		token = jsonencode({
+			type       = "card"
+			data       = "{{ encrypted | json: '$.data' }}"
+			containers = ["/pci/high/"]
+		})
+	}`),
+				Check: testAccCaptureResourceID("basistheory_proxy.terraform_test_proxy", &createdID),
+			},
+			{
+				Config: buildProxyWithRequestTransformAttributes(`
+	type = "tokenize"
+	options {
+		identifier = "outputTokenA"
+		token_template {
+			type       = "card"
+			data       = "{{ encrypted | json: '$.data' }}"
+			containers = ["/pci/high/"]
+		}
+	}`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token_template.0.type", "card"),
+					resource.TestCheckResourceAttr(
+						"basistheory_proxy.terraform_test_proxy", "request_transforms.0.options.0.token", ""),
+					testAccCheckResourceID("basistheory_proxy.terraform_test_proxy", &createdID, true),
+				),
+			},
+		},
+	})
+}
+
 func TestResourceProxyWithTwoRequestTransforms(t *testing.T) {
 	skipForVaultApiCaching(t)
 	resource.UnitTest(t, resource.TestCase{
@@ -998,20 +1149,14 @@ func deleteProxyExternally(resourceName string) resource.TestCheckFunc {
 			return fmt.Errorf("resource not found: %s", resourceName)
 		}
 
-		client := basistheoryClient.NewClient(
-			option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-			option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-		)
+		client := newTestAPIClient()
 
 		return client.Proxies.Delete(context.TODO(), rs.Primary.ID)
 	}
 }
 
 func testAccCheckProxyDestroy(state *terraform.State) error {
-	basisTheoryClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	basisTheoryClient := newTestAPIClient()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "basistheory_proxy" {
@@ -1535,5 +1680,347 @@ resource "basistheory_proxy" "response_transform_proxy" {
 // the framework's post-apply refresh and destroy verification intermittently observe
 // stale data and fail. Re-enable these once the vault-api caching fix lands (ENG-11478).
 func skipForVaultApiCaching(t *testing.T) {
+	if testFakeAPI != nil {
+		return
+	}
+
 	t.Skip("blocked by known dev vault-api read-after-write caching issue (tracked separately); ENG-11478")
 }
+
+// testUnknownConfigValue is how Terraform represents values that are unknown
+// until apply in a raw ResourceConfig.
+const testUnknownConfigValue = "74D93920-ED26-11E3-AC10-0800200C9A66"
+
+func testProxyPlanError(t *testing.T, requestTransforms []interface{}) error {
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":               "Terraform proxy",
+		"destination_url":    "https://httpbin.org/post",
+		"request_transforms": requestTransforms,
+	})
+
+	_, err := resourceBasisTheoryProxy().Diff(context.Background(), nil, config, nil)
+	return err
+}
+
+func TestResourceProxyCustomizeDiff_reportsInvalidTransformsWithAttributePath(t *testing.T) {
+	err := testProxyPlanError(t, []interface{}{
+		map[string]interface{}{"type": "code", "code": "module.exports = async function (context) { return context; };"},
+		map[string]interface{}{"type": "mask", "matcher": "regex", "expression": "(.*)"},
+	})
+
+	var pathError cty.PathError
+	if !errors.As(err, &pathError) {
+		t.Fatalf("expected a path error, got %v", err)
+	}
+	if expected := cty.GetAttrPath("request_transforms").IndexInt(1); !pathError.Path.Equals(expected) {
+		t.Fatalf("expected path %#v, got %#v", expected, pathError.Path)
+	}
+	if !strings.Contains(err.Error(), "request_transforms[1]: replacement is required when type is 'mask'") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
+func TestResourceProxyCustomizeDiff_reportsErrorsAcrossTransforms(t *testing.T) {
+	err := testProxyPlanError(t, []interface{}{
+		map[string]interface{}{"type": "code", "code": "module.exports = async function (context) { return context; };"},
+		map[string]interface{}{"type": "code", "code": "module.exports = async function (context) { return context; };"},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "only one CODE transform is allowed in request_transforms") {
+		t.Fatalf("expected code transform error, got %v", err)
+	}
+}
+
+func TestResourceProxyCustomizeDiff_skipsValuesUnknownAtPlan(t *testing.T) {
+	err := testProxyPlanError(t, []interface{}{
+		map[string]interface{}{"type": "mask", "matcher": "regex", "expression": "(.*)", "replacement": testUnknownConfigValue},
+		map[string]interface{}{"type": "code", "code": testUnknownConfigValue},
+	})
+
+	if err != nil {
+		t.Fatalf("expected unknown values to be skipped, got %s", err)
+	}
+}
+
+func TestJsonEqual_ignoresFormattingAndEmptyDefaults(t *testing.T) {
+	config := `{"type":"token","data":"{{ body.card }}","containers":["/pci/"]}`
+
+	equivalent := []string{
+		`{ "containers": ["/pci/"], "type": "token", "data": "{{ body.card }}" }`,
+		`{"containers":["/pci/"],"data":"{{ body.card }}","metadata":null,"search_indexes":[],"type":"token"}`,
+		`{"containers":["/pci/"],"data":"{{ body.card }}","privacy":{},"type":"token","expires_at":null}`,
+	}
+	for _, state := range equivalent {
+		if !jsonEqual(state, config) {
+			t.Fatalf("expected %s to equal %s", state, config)
+		}
+	}
+
+	different := []string{
+		`{"containers":["/general/"],"data":"{{ body.card }}","type":"token"}`,
+		`{"containers":["/pci/"],"data":"{{ body.card }}","type":"card_number"}`,
+		`{"containers":["/pci/"],"data":"{{ body.card }}","type":"token","metadata":{"source":"proxy"}}`,
+		``,
+		`not json`,
+	}
+	for _, state := range different {
+		if jsonEqual(state, config) {
+			t.Fatalf("expected %s to differ from %s", state, config)
+		}
+	}
+}
+
+func TestResourceProxy_suppressesEquivalentTokenDiffs(t *testing.T) {
+	for _, fieldName := range []string{"request_transforms", "response_transforms"} {
+		options := resourceBasisTheoryProxy().Schema[fieldName].Elem.(*schema.Resource).Schema["options"].Elem.(*schema.Resource)
+		token := options.Schema["token"]
+
+		if token.DiffSuppressFunc == nil {
+			t.Fatalf("expected %s options.token to suppress equivalent JSON diffs", fieldName)
+		}
+		if !token.DiffSuppressFunc(fieldName+".0.options.0.token", `{"type":"token","metadata":null}`, `{ "type": "token" }`, nil) {
+			t.Fatalf("expected %s options.token diff to be suppressed", fieldName)
+		}
+	}
+}
+
+func TestResourceProxyCustomizeDiff_rejectsTokenAndTokenTemplate(t *testing.T) {
+	err := testProxyPlanError(t, []interface{}{
+		map[string]interface{}{
+			"type": "tokenize",
+			"options": []interface{}{
+				map[string]interface{}{
+					"token":          `{"type":"token","data":"{{ body.card }}"}`,
+					"token_template": []interface{}{map[string]interface{}{"type": "token", "data": "{{ body.card }}"}},
+				},
+			},
+		},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "only one of token or token_template can be set") {
+		t.Fatalf("expected token and token_template to conflict, got %v", err)
+	}
+
+	err = testProxyPlanError(t, []interface{}{
+		map[string]interface{}{
+			"type": "tokenize",
+			"options": []interface{}{
+				map[string]interface{}{
+					"token_template": []interface{}{map[string]interface{}{"type": "token", "data": "{{ body.card }}"}},
+				},
+			},
+		},
+	})
+
+	if err != nil {
+		t.Fatalf("expected token_template to satisfy tokenize options, got %s", err)
+	}
+}
+
+func TestGetProxyFromData_expandsTokenTemplate(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryProxy().Schema, map[string]interface{}{
+		"name":            "Terraform proxy with token template",
+		"destination_url": "https://httpbin.org/post",
+		"request_transforms": []interface{}{
+			map[string]interface{}{
+				"type": "tokenize",
+				"options": []interface{}{
+					map[string]interface{}{
+						"token_template": []interface{}{
+							map[string]interface{}{
+								"type":              "card",
+								"data":              `{"number":"{{ body.number }}","expiration_month":"{{ body.month }}"}`,
+								"containers":        []interface{}{"/pci/high/"},
+								"metadata":          map[string]interface{}{"source": "proxy"},
+								"search_indexes":    []interface{}{"{{ data.number | last4 }}"},
+								"mask":              "{{ data.number | reveal_last: 4 }}",
+								"deduplicate_token": true,
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+
+	proxy, err := getProxyFromData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	token := proxy.RequestTransforms[0].Options.Token
+
+	if token == nil || getStringValue(token.Type) != "card" {
+		t.Fatalf("expected card token request, got %+v", token)
+	}
+	if tokenData, ok := token.Data.(map[string]interface{}); !ok || tokenData["number"] != "{{ body.number }}" {
+		t.Fatalf("expected JSON data to be sent as an object, got %#v", token.Data)
+	}
+	if token.Mask != "{{ data.number | reveal_last: 4 }}" {
+		t.Fatalf("expected string mask to be sent as a string, got %#v", token.Mask)
+	}
+	if len(token.Containers) != 1 || token.Containers[0] != "/pci/high/" {
+		t.Fatalf("unexpected containers: %v", token.Containers)
+	}
+	if source := token.Metadata["source"]; source == nil || *source != "proxy" {
+		t.Fatalf("unexpected metadata: %v", token.Metadata)
+	}
+	if token.DeduplicateToken == nil || !*token.DeduplicateToken {
+		t.Fatalf("expected deduplicate_token to be sent")
+	}
+}
+
+func TestSetProxyState_flattensTokenIntoConfiguredForm(t *testing.T) {
+	tokenType := "card"
+	tokenData := "{{ body.number }}"
+	id := "proxy-id"
+	transformType := "tokenize"
+	proxy := &basistheory.Proxy{
+		ID: &id,
+		RequestTransforms: []*basistheory.ProxyTransform{
+			{
+				Type:    &transformType,
+				Options: &basistheory.ProxyTransformOptions{Token: &basistheory.CreateTokenRequest{Type: &tokenType, Data: tokenData, Containers: []string{"/pci/high/"}}},
+			},
+		},
+		ResponseTransforms: []*basistheory.ProxyTransform{
+			{
+				Type:    &transformType,
+				Options: &basistheory.ProxyTransformOptions{Token: &basistheory.CreateTokenRequest{Type: &tokenType, Data: tokenData}},
+			},
+		},
+	}
+
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryProxy().Schema, map[string]interface{}{
+		"request_transforms": []interface{}{
+			map[string]interface{}{
+				"type": "tokenize",
+				"options": []interface{}{
+					map[string]interface{}{
+						"token_template": []interface{}{map[string]interface{}{"type": "card", "data": "{{ body.number }}"}},
+					},
+				},
+			},
+		},
+		"response_transforms": []interface{}{
+			map[string]interface{}{
+				"type": "tokenize",
+				"options": []interface{}{
+					map[string]interface{}{"token": `{"type":"card","data":"{{ body.number }}"}`},
+				},
+			},
+		},
+	})
+
+	if diags := setProxyState(data, proxy); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if actual := data.Get("request_transforms.0.options.0.token_template.0.containers.0"); actual != "/pci/high/" {
+		t.Fatalf("expected request token to be flattened into token_template, got %v", actual)
+	}
+	if actual := data.Get("request_transforms.0.options.0.token"); actual != "" {
+		t.Fatalf("expected request token string to be empty, got %v", actual)
+	}
+	var responseToken basistheory.CreateTokenRequest
+	if err := json.Unmarshal([]byte(data.Get("response_transforms.0.options.0.token").(string)), &responseToken); err != nil || getStringValue(responseToken.Type) != "card" {
+		t.Fatalf("expected response token to stay a JSON string, got %v", data.Get("response_transforms.0.options.0.token"))
+	}
+	if actual := data.Get("response_transforms.0.options.0.token_template.#"); actual != 0 {
+		t.Fatalf("expected response token_template to be empty, got %v", actual)
+	}
+}
+
+func TestGetProxyFromData_readsCodeFile(t *testing.T) {
+	codeFile := writeTestCodeFile(t, t.TempDir(), "transform.js", "module.exports = async function (req) { return req; };")
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryProxy().Schema, map[string]interface{}{
+		"name":            "Terraform proxy",
+		"destination_url": "https://httpbin.org/post",
+		"request_transforms": []interface{}{
+			map[string]interface{}{"type": "code", "code_file": codeFile},
+		},
+	})
+
+	proxy, err := getProxyFromData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if code := getStringValue(proxy.RequestTransforms[0].Code); code != "module.exports = async function (req) { return req; };" {
+		t.Fatalf("expected code to be read from code_file, got %q", code)
+	}
+}
+
+func TestResourceProxyCustomizeDiff_hashesCodeFile(t *testing.T) {
+	codeFile := writeTestCodeFile(t, t.TempDir(), "transform.js", "module.exports = async function (req) { return req; };")
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":            "Terraform proxy",
+		"destination_url": "https://httpbin.org/post",
+		"request_transforms": []interface{}{
+			map[string]interface{}{"type": "code", "code_file": codeFile},
+		},
+	})
+
+	diff, err := resourceBasisTheoryProxy().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := codeSha256("module.exports = async function (req) { return req; };")
+	if attribute := diff.Attributes["code_sha256.request_transforms"]; attribute == nil || attribute.New != expected {
+		t.Fatalf("expected code_sha256.request_transforms to be %s, got %#v", expected, attribute)
+	}
+}
+
+func TestResourceProxyCustomizeDiff_reportsUnreadableCodeFile(t *testing.T) {
+	err := testProxyPlanError(t, []interface{}{
+		map[string]interface{}{"type": "code", "code_file": filepath.Join(t.TempDir(), "missing.js")},
+	})
+
+	var pathError cty.PathError
+	if !errors.As(err, &pathError) {
+		t.Fatalf("expected a path error, got %v", err)
+	}
+	if expected := cty.GetAttrPath("request_transforms").IndexInt(0).GetAttr("code_file"); !pathError.Path.Equals(expected) {
+		t.Fatalf("expected path %#v, got %#v", expected, pathError.Path)
+	}
+}
+
+func TestResourceProxyCustomizeDiff_rejectsCodeAndCodeFile(t *testing.T) {
+	err := testProxyPlanError(t, []interface{}{
+		map[string]interface{}{"type": "code", "code": "module.exports = {};", "code_file": "transform.js"},
+	})
+
+	if err == nil || !strings.Contains(err.Error(), "only one of code or code_file can be set") {
+		t.Fatalf("expected code and code_file to conflict, got %v", err)
+	}
+}
+
+func TestSetProxyState_keepsCodeFileAndHashesCode(t *testing.T) {
+	id := "proxy-id"
+	transformType := "code"
+	code := "module.exports = async function (req) { return req; };"
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryProxy().Schema, map[string]interface{}{
+		"request_transforms": []interface{}{
+			map[string]interface{}{"type": "code", "code_file": "transform.js"},
+		},
+	})
+
+	diags := setProxyState(data, &basistheory.Proxy{
+		ID:                &id,
+		RequestTransforms: []*basistheory.ProxyTransform{{Type: &transformType, Code: &code}},
+	})
+	if diags.HasError() {
+		t.Fatalf("unexpected diagnostics: %v", diags)
+	}
+
+	if actual := data.Get("request_transforms.0.code").(string); actual != "" {
+		t.Fatalf("expected code to be left out of state, got %q", actual)
+	}
+	if actual := data.Get("request_transforms.0.code_file").(string); actual != "transform.js" {
+		t.Fatalf("expected code_file to be kept, got %q", actual)
+	}
+	if actual := data.Get("code_sha256.request_transforms").(string); actual != codeSha256(code) {
+		t.Fatalf("expected code_sha256 to hash the deployed code, got %q", actual)
+	}
+}
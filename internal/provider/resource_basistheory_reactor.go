@@ -7,7 +7,9 @@ import (
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
 	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -19,10 +21,18 @@ func resourceBasisTheoryReactor() *schema.Resource {
 			StateContext: schema.ImportStatePassthroughContext,
 		},
 
-		CreateContext: resourceReactorCreate,
-		ReadContext:   resourceReactorRead,
-		UpdateContext: resourceReactorUpdate,
-		DeleteContext: resourceReactorDelete,
+		CreateWithoutTimeout: warnUnknownPermissions(resourceReactorCreate, reactorPermissionsFromData),
+		ReadContext:          resourceReactorRead,
+		UpdateWithoutTimeout: warnUnknownPermissions(resourceReactorUpdate, reactorPermissionsFromData),
+		DeleteWithoutTimeout: resourceReactorDelete,
+
+		CustomizeDiff: customdiff.All(
+			resourceReactorCustomizeDiff,
+			resourceReactorPermissionsCustomizeDiff,
+			sensitiveConfigurationCustomizeDiff,
+		),
+
+		Timeouts: provisioningResourceTimeouts(),
 
 		Schema: map[string]*schema.Schema{
 			"id": {
@@ -36,9 +46,27 @@ func resourceBasisTheoryReactor() *schema.Resource {
 				Required:    true,
 			},
 			"code": {
-				Description: "The code that is executed when the Reactor runs",
+				Description:  "The code that is executed when the Reactor runs. Exactly one of `code`, `code_file` or `code_dir` must be set",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: reactorCodeKeys,
+			},
+			"code_file": {
+				Description:  "Path to a file holding the code that is executed when the Reactor runs. Only its hash is kept in state",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: reactorCodeKeys,
+			},
+			"code_dir": {
+				Description:  "Path to a directory whose `.js` files are bundled into the code that is executed when the Reactor runs. The directory must contain an `index.js` entrypoint whose exports become the Reactor's; relative `require` calls between the files are resolved in the bundle and all others, such as runtime dependencies, are left to the Reactor runtime. `node_modules` and hidden directories are skipped. Only the bundle's hash is kept in state",
+				Type:         schema.TypeString,
+				Optional:     true,
+				ExactlyOneOf: reactorCodeKeys,
+			},
+			"code_sha256": {
+				Description: "SHA-256 hash of the Reactor's code",
 				Type:        schema.TypeString,
-				Required:    true,
+				Computed:    true,
 			},
 			"application_id": {
 				Description: "The Application's permissions used in the BasisTheory instance passed into the Reactor",
@@ -59,6 +87,7 @@ func resourceBasisTheoryReactor() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			sensitiveConfigurationKey: sensitiveConfigurationSchema("Reactor"),
 			"runtime": {
 				Description: "Runtime configuration for the Reactor",
 				Type:        schema.TypeList,
@@ -125,6 +154,7 @@ func resourceBasisTheoryReactor() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			"requested": provisioningRequestedSchema("Reactor"),
 			"created_at": {
 				Description: "Timestamp at which the Reactor was created",
 				Type:        schema.TypeString,
@@ -145,14 +175,87 @@ func resourceBasisTheoryReactor() *schema.Resource {
 				Type:        schema.TypeString,
 				Computed:    true,
 			},
+			deletionProtectionKey: deletionProtectionSchema("Reactor"),
+			waitForActiveKey:      waitForActiveSchema("Reactor"),
+			acceptStatesKey:       acceptStatesSchema("Reactor"),
 		},
 	}
 }
 
+var reactorCodeKeys = []string{"code", "code_file", "code_dir"}
+
+// resourceReactorCustomizeDiff hashes the configured code at plan time, so a
+// change to a code_file or code_dir shows up as a code_sha256 change.
+func resourceReactorCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	for _, key := range reactorCodeKeys {
+		if !diff.NewValueKnown(key) {
+			return diff.SetNewComputed("code_sha256")
+		}
+	}
+
+	code, err := reactorCode(diff.Get("code").(string), diff.Get("code_file").(string), diff.Get("code_dir").(string))
+	if err != nil {
+		return err
+	}
+
+	if codeHash := codeSha256(code); codeHash != diff.Get("code_sha256").(string) {
+		return diff.SetNew("code_sha256", codeHash)
+	}
+
+	return nil
+}
+
+// resourceReactorPermissionsCustomizeDiff checks at plan time that the
+// runtime is only granted permissions a private Application can hold.
+func resourceReactorPermissionsCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, meta interface{}) error {
+	if !diff.NewValueKnown("runtime.0.permissions") {
+		return nil
+	}
+
+	permissions, _ := diff.Get("runtime.0.permissions").([]interface{})
+	catalog, refreshed := permissionCatalogFromMeta(meta)
+
+	return joinPathErrors(catalog.validatePermissions(runtimePermissionApplicationType, permissions, cty.GetAttrPath("runtime").IndexInt(0).GetAttr("permissions"), true, refreshed))
+}
+
+// reactorPermissionsFromData returns the permissions granted to the runtime.
+func reactorPermissionsFromData(data *schema.ResourceData) (string, []interface{}) {
+	permissions, _ := data.Get("runtime.0.permissions").([]interface{})
+
+	return runtimePermissionApplicationType, permissions
+}
+
+// reactorCode returns the code configured inline, read from code_file or
+// bundled from code_dir. Errors are cty.PathErrors on the attribute set.
+func reactorCode(code string, codeFile string, codeDir string) (string, error) {
+	switch {
+	case codeFile != "":
+		code, err := readCodeFile(codeFile)
+		if err != nil {
+			return "", cty.GetAttrPath("code_file").NewError(err)
+		}
+		return code, nil
+	case codeDir != "":
+		code, err := bundleCodeDir(codeDir)
+		if err != nil {
+			return "", cty.GetAttrPath("code_dir").NewError(err)
+		}
+		return code, nil
+	default:
+		return code, nil
+	}
+}
+
 func resourceReactorCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx, cancel := withProvisioningTimeout(ctx, data, meta, schema.TimeoutCreate)
+	defer cancel()
+
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
-	reactor := getReactorFromData(data)
+	reactor, err := getReactorFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
 
 	createReactorRequest := &basistheory.CreateReactorRequest{
 		Name:          getStringValue(reactor.Name),
@@ -165,31 +268,34 @@ func resourceReactorCreate(ctx context.Context, data *schema.ResourceData, meta
 	createdReactor, err := basisTheoryClient.Reactors.Create(ctx, createReactorRequest)
 
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Reactor:", err)
+		return remapAttributePaths(apiErrorDiagnosticsWithAttributePaths("Error creating Reactor:", err, resourceBasisTheoryReactor().Schema), sensitiveConfigurationAttributePath(data))
 	}
 
 	data.SetId(*createdReactor.ID)
 
 	// Wait for the reactor to reach a final state before returning
-	if diags := waitForReactorFinalState(ctx, basisTheoryClient, data.Id()); diags != nil {
-		return diags
+	var diags diag.Diagnostics
+	if data.Get(waitForActiveKey).(bool) {
+		diags = waitForReactorFinalState(ctx, basisTheoryClient, data.Id(), provisioningAcceptedStates(data))
+		if diags.HasError() {
+			return diags
+		}
 	}
 
-	return resourceReactorRead(ctx, data, meta)
+	return append(diags, resourceReactorRead(ctx, data, meta)...)
 }
 
-func waitForReactorFinalState(ctx context.Context, client *basistheoryClient.Client, id string) diag.Diagnostics {
-	// Poll every 2 seconds up to 10 minutes
-	interval := 2 * time.Second
-	deadline := time.Now().Add(10 * time.Minute)
-
-	for {
-		if time.Now().After(deadline) {
-			return diag.Errorf("timeout waiting for reactor %s to reach a final state", id)
-		}
-
+// waitForReactorFinalState polls until the reactor settles. Accepted states
+// other than active are returned as warnings.
+func waitForReactorFinalState(ctx context.Context, client *basistheoryClient.Client, id string, acceptedStates []string) diag.Diagnostics {
+	// Poll with exponential backoff until ctx, bounded by the resource's
+	// timeouts, expires
+	for attempt := 0; ; attempt++ {
 		reactor, err := client.Reactors.Get(ctx, id)
 		if err != nil {
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return diag.Errorf("timeout waiting for reactor %s to reach a final state", id)
+			}
 			return apiErrorDiagnostics("Error polling Reactor:", err)
 		}
 
@@ -202,13 +308,19 @@ func waitForReactorFinalState(ctx context.Context, client *basistheoryClient.Cli
 		case "active":
 			return nil
 		case "failed", "outdated":
+			if containsString(acceptedStates, state) {
+				return provisioningWarnings(reactorFinalStateDiagnostics(id, state, reactor))
+			}
 			return reactorFinalStateDiagnostics(id, state, reactor)
 		}
 
 		select {
 		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				return diag.Errorf("timeout waiting for reactor %s to reach a final state", id)
+			}
 			return diag.FromErr(ctx.Err())
-		case <-time.After(interval):
+		case <-time.After(provisioningPollInterval(attempt)):
 		}
 	}
 }
@@ -258,8 +370,44 @@ func resourceReactorRead(ctx context.Context, data *schema.ResourceData, meta in
 		return apiErrorDiagnostics("Error reading Reactor:", err)
 	}
 
+	return setReactorState(data, reactor)
+}
+
+// setReactorState writes an API reactor object into resource state, keeping
+// local-only attributes from the resource configuration. The data sources use
+// setReactorDataSourceState.
+func setReactorState(data *schema.ResourceData, reactor *basistheory.Reactor) diag.Diagnostics {
 	data.SetId(*reactor.ID)
 
+	reactorData := flattenReactor(reactor)
+	reactorData["configuration"], reactorData[sensitiveConfigurationKey] = splitConfiguration(data, reactor.Configuration)
+	// Keeps the defaults in state for imported Reactors
+	for _, key := range provisioningLocalKeys {
+		reactorData[key] = data.Get(key)
+	}
+	useReactorCodeFile(data, reactorData)
+
+	for reactorDatumName, reactorDatum := range reactorData {
+		err := data.Set(reactorDatumName, reactorDatum)
+
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// useReactorCodeFile leaves code out of state for Reactors configured from
+// code_file or code_dir, which are only tracked through code_sha256. Those
+// attributes only exist on the resource, so the data sources never call it.
+func useReactorCodeFile(data *schema.ResourceData, reactorData map[string]interface{}) {
+	if data.Get("code_file").(string) != "" || data.Get("code_dir").(string) != "" {
+		delete(reactorData, "code")
+	}
+}
+
+func flattenReactor(reactor *basistheory.Reactor) map[string]interface{} {
 	application := reactor.Application
 
 	modifiedAt := ""
@@ -268,9 +416,46 @@ func resourceReactorRead(ctx context.Context, data *schema.ResourceData, meta in
 		modifiedAt = reactor.ModifiedAt.String()
 	}
 
-	runtime := reactor.Runtime
+	createdAt := ""
+
+	if reactor.CreatedAt != nil {
+		createdAt = reactor.CreatedAt.String()
+	}
+
+	return map[string]interface{}{
+		"id":          reactor.ID,
+		"tenant_id":   reactor.TenantID,
+		"name":        reactor.Name,
+		"code":        reactor.Code,
+		"code_sha256": codeSha256(getStringValue(reactor.Code)),
+		"application_id": func() interface{} {
+			if application != nil {
+				return application.ID
+			}
+			return nil
+		}(),
+		"configuration": reactor.Configuration,
+		"runtime":       flattenReactorRuntime(reactor.Runtime),
+		"state":         reactor.State,
+		"requested":     flattenReactorRequested(reactor.GetRequested()),
+		"created_at":    createdAt,
+		"created_by":    reactor.CreatedBy,
+		"modified_at":   modifiedAt,
+		"modified_by":   reactor.ModifiedBy,
+	}
+}
+
+func flattenReactorRequested(requested *basistheory.RequestedReactor) []interface{} {
+	if requested == nil {
+		return []interface{}{}
+	}
+
+	return flattenProvisioningRequested(requested.GetState(), requested.GetErrorCode(), requested.GetErrorMessage(), requested.GetErrorDetails())
+}
 
-	// Flatten runtime to Terraform state (single block)
+// flattenReactorRuntime flattens the runtime into a single block, or nil to
+// clear it when the API returns no runtime settings.
+func flattenReactorRuntime(runtime *basistheory.ReactorRuntime) []interface{} {
 	runtimeMap := map[string]interface{}{}
 	if runtime != nil {
 		if v := runtime.Async; v != nil {
@@ -310,48 +495,31 @@ func resourceReactorRead(ctx context.Context, data *schema.ResourceData, meta in
 			runtimeMap["permissions"] = v
 		}
 	}
-	if len(runtimeMap) > 0 {
-		if err := data.Set("runtime", []interface{}{runtimeMap}); err != nil {
-			return diag.FromErr(err)
-		}
-	} else {
-		// Clear runtime if not present
-		if err := data.Set("runtime", nil); err != nil {
-			return diag.FromErr(err)
-		}
-	}
-
-	for reactorDatumName, reactorDatum := range map[string]interface{}{
-		"tenant_id": reactor.TenantID,
-		"name":      reactor.Name,
-		"code":      reactor.Code,
-		"application_id": func() interface{} {
-			if application != nil {
-				return application.ID
-			}
-			return nil
-		}(),
-		"configuration": reactor.Configuration,
-		"state":         reactor.State,
-		"created_at":    reactor.CreatedAt.String(),
-		"created_by":    reactor.CreatedBy,
-		"modified_at":   modifiedAt,
-		"modified_by":   reactor.ModifiedBy,
-	} {
-		err := data.Set(reactorDatumName, reactorDatum)
 
-		if err != nil {
-			return diag.FromErr(err)
-		}
+	if len(runtimeMap) == 0 {
+		return nil
 	}
 
-	return nil
+	return []interface{}{runtimeMap}
 }
 
 func resourceReactorUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	// deletion_protection and the provisioning wait settings only live in
+	// Terraform state
+	if !data.HasChangesExcept(provisioningLocalKeys...) {
+		return nil
+	}
+
+	ctx, cancel := withProvisioningTimeout(ctx, data, meta, schema.TimeoutUpdate)
+	defer cancel()
+
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
-	reactor := getReactorFromData(data)
+	reactor, err := getReactorFromData(data)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
 	updateReactorRequest := &basistheory.UpdateReactorRequest{
 		Name:          getStringValue(reactor.Name),
 		Code:          getStringValue(reactor.Code),
@@ -359,21 +527,32 @@ func resourceReactorUpdate(ctx context.Context, data *schema.ResourceData, meta
 		Application:   reactor.Application,
 		Runtime:       reactor.Runtime,
 	}
-	_, err := basisTheoryClient.Reactors.Update(ctx, *reactor.ID, updateReactorRequest)
+	_, err = basisTheoryClient.Reactors.Update(ctx, *reactor.ID, updateReactorRequest)
 
 	if err != nil {
-		return apiErrorDiagnostics("Error updating Reactor:", err)
+		return remapAttributePaths(apiErrorDiagnosticsWithAttributePaths("Error updating Reactor:", err, resourceBasisTheoryReactor().Schema), sensitiveConfigurationAttributePath(data))
 	}
 
 	// Wait for the reactor to reach a final state before returning
-	if diags := waitForReactorFinalState(ctx, basisTheoryClient, data.Id()); diags != nil {
-		return diags
+	var diags diag.Diagnostics
+	if data.Get(waitForActiveKey).(bool) {
+		diags = waitForReactorFinalState(ctx, basisTheoryClient, data.Id(), provisioningAcceptedStates(data))
+		if diags.HasError() {
+			return diags
+		}
 	}
 
-	return resourceReactorRead(ctx, data, meta)
+	return append(diags, resourceReactorRead(ctx, data, meta)...)
 }
 
 func resourceReactorDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if diags := deletionProtectionDiagnostics(data, "Reactor"); diags != nil {
+		return diags
+	}
+
+	ctx, cancel := withProvisioningTimeout(ctx, data, meta, schema.TimeoutDelete)
+	defer cancel()
+
 	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
 
 	err := basisTheoryClient.Reactors.Delete(ctx, data.Id())
@@ -385,23 +564,20 @@ func resourceReactorDelete(ctx context.Context, data *schema.ResourceData, meta
 	return nil
 }
 
-func getReactorFromData(data *schema.ResourceData) *basistheory.Reactor {
+func getReactorFromData(data *schema.ResourceData) (*basistheory.Reactor, error) {
 	reactor := &basistheory.Reactor{}
 	reactor.ID = getStringPointer(data.Id())
 	reactor.Name = getStringPointer(data.Get("name"))
 
-	reactorCode := data.Get("code").(string)
-	if reactorCode != "" {
-		reactor.Code = getStringPointer(reactorCode)
+	code, err := reactorCode(data.Get("code").(string), data.Get("code_file").(string), data.Get("code_dir").(string))
+	if err != nil {
+		return nil, err
 	}
-
-	configOptions := map[string]*string{}
-	if cfg, ok := data.GetOk("configuration"); ok {
-		for key, value := range cfg.(map[string]interface{}) {
-			configOptions[key] = getStringPointer(value)
-		}
+	if code != "" {
+		reactor.Code = getStringPointer(code)
 	}
-	reactor.Configuration = configOptions
+
+	reactor.Configuration = configurationFromData(data)
 
 	// Application
 	applicationId := data.Get("application_id").(string)
@@ -462,5 +638,5 @@ func getReactorFromData(data *schema.ResourceData) *basistheory.Reactor {
 		}
 	}
 
-	return reactor
+	return reactor, nil
 }
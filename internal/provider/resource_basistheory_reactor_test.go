@@ -5,13 +5,14 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
 	"regexp"
 	"strings"
 	"testing"
 
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
-	"github.com/Basis-Theory/go-sdk/v7/option"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -91,6 +92,57 @@ func TestReactorFinalStateDiagnostics_includesRequestedError(t *testing.T) {
 	}
 }
 
+func TestFlattenReactorRequested_includesVulnerabilities(t *testing.T) {
+	state := "outdated"
+	errorCode := "vulnerabilities_detected"
+	reactor := &basistheory.Reactor{
+		Requested: &basistheory.RequestedReactor{
+			State:     &state,
+			ErrorCode: &errorCode,
+			ErrorDetails: map[string]interface{}{
+				"vulnerabilities": []interface{}{
+					map[string]interface{}{
+						"name":            "follow-redirects",
+						"version":         "1.14.7",
+						"severity":        "HIGH",
+						"id":              "CVE-2022-0536",
+						"dependency_path": []interface{}{"axios", "follow-redirects"},
+					},
+					"not a vulnerability",
+				},
+			},
+		},
+	}
+
+	actual := flattenReactor(reactor)["requested"].([]interface{})
+
+	expected := []interface{}{
+		map[string]interface{}{
+			"state":         "outdated",
+			"error_code":    "vulnerabilities_detected",
+			"error_message": "",
+			"vulnerabilities": []interface{}{
+				map[string]interface{}{
+					"name":            "follow-redirects",
+					"version":         "1.14.7",
+					"severity":        "HIGH",
+					"id":              "CVE-2022-0536",
+					"dependency_path": []interface{}{"axios", "follow-redirects"},
+				},
+			},
+		},
+	}
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, actual)
+	}
+}
+
+func TestFlattenReactorRequested_withoutRequested(t *testing.T) {
+	if actual := flattenReactorRequested(nil); actual == nil || len(actual) != 0 {
+		t.Fatalf("expected an empty, non-nil list, got %#v", actual)
+	}
+}
+
 func TestGetReactorFromData_includesRuntimeResolutions(t *testing.T) {
 	data := schema.TestResourceDataRaw(t, resourceBasisTheoryReactor().Schema, map[string]interface{}{
 		"name": "Terraform reactor with node22 runtime",
@@ -108,7 +160,10 @@ func TestGetReactorFromData_includesRuntimeResolutions(t *testing.T) {
 		},
 	})
 
-	reactor := getReactorFromData(data)
+	reactor, err := getReactorFromData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
 
 	resolutions := reactor.Runtime.Resolutions
 	if actual := resolutions["follow-redirects"]; actual == nil || *actual != "1.15.6" {
@@ -128,7 +183,10 @@ func TestGetReactorFromData_includesRuntimeAsync(t *testing.T) {
 		},
 	})
 
-	reactor := getReactorFromData(data)
+	reactor, err := getReactorFromData(data)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
 
 	if reactor.Runtime.Async == nil || !*reactor.Runtime.Async {
 		t.Fatalf("expected async runtime to be enabled, got %v", reactor.Runtime.Async)
@@ -179,6 +237,95 @@ func TestResourceReactor(t *testing.T) {
 	})
 }
 
+func TestResourceReactorWithCodeFile(t *testing.T) {
+	codeFile := filepath.Join(t.TempDir(), "reactor.js")
+	writeCode := func(code string) func() {
+		return func() {
+			if err := os.WriteFile(codeFile, []byte(code), 0o644); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+		}
+	}
+	config := fmt.Sprintf(`
+resource "basistheory_reactor" "terraform_test_reactor_code_file" {
+  name      = "Terraform reactor from file"
+  code_file = %q
+}
+`, codeFile)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckReactorDestroy,
+		Steps: []resource.TestStep{
+			{
+				PreConfig: writeCode("module.exports = async function (context) { return context; };"),
+				Config:    config,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_reactor_code_file", "code", ""),
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_reactor_code_file", "code_sha256", codeSha256("module.exports = async function (context) { return context; };")),
+				),
+			},
+			{
+				PreConfig: writeCode("module.exports = async function (context) { return { raw: context.args }; };"),
+				Config:    config,
+				Check: resource.TestCheckResourceAttr(
+					"basistheory_reactor.terraform_test_reactor_code_file", "code_sha256", codeSha256("module.exports = async function (context) { return { raw: context.args }; };")),
+			},
+		},
+	})
+}
+
+func TestResourceReactorCustomizeDiff_hashesCodeFromFiles(t *testing.T) {
+	dir := t.TempDir()
+	codeFile := writeTestCodeFile(t, dir, "file/reactor.js", "module.exports = async function (context) { return context; };")
+	writeTestCodeFile(t, dir, "bundle/index.js", "module.exports = async function (context) { return context; };")
+	bundle, err := bundleCodeDir(filepath.Join(dir, "bundle"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for key, testCase := range map[string]struct {
+		path     string
+		expected string
+	}{
+		"code_file": {codeFile, codeSha256("module.exports = async function (context) { return context; };")},
+		"code_dir":  {filepath.Join(dir, "bundle"), codeSha256(bundle)},
+	} {
+		diff, err := resourceBasisTheoryReactor().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name": "Terraform reactor",
+			key:    testCase.path,
+		}), nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", key, err)
+		}
+
+		if attribute := diff.Attributes["code_sha256"]; attribute == nil || attribute.New != testCase.expected {
+			t.Fatalf("%s: expected code_sha256 to be %s, got %#v", key, testCase.expected, attribute)
+		}
+	}
+}
+
+func TestResourceReactorCustomizeDiff_reportsUnreadableCodeDir(t *testing.T) {
+	_, err := resourceBasisTheoryReactor().Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"name":     "Terraform reactor",
+		"code_dir": t.TempDir(),
+	}), nil)
+
+	var pathError cty.PathError
+	if !errors.As(err, &pathError) {
+		t.Fatalf("expected a path error, got %v", err)
+	}
+	if !pathError.Path.Equals(cty.GetAttrPath("code_dir")) {
+		t.Fatalf("expected path to code_dir, got %#v", pathError.Path)
+	}
+	if !strings.Contains(err.Error(), "has no index.js entrypoint") {
+		t.Fatalf("unexpected error: %s", err)
+	}
+}
+
 func TestResourceReactorWithoutApplication(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { preCheck(t) },
@@ -203,6 +350,52 @@ func TestResourceReactorWithoutApplication(t *testing.T) {
 	})
 }
 
+func TestResourceReactor_ReportsFailedProvisioning(t *testing.T) {
+	fakeAPIOnly(t).setProvisioningOutcome("Terraform failing reactor", "failed")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "basistheory_reactor" "terraform_test_failing_reactor" {
+  name = "Terraform failing reactor"
+  code = "module.exports = async function (context) { return context; };"
+}
+`,
+				ExpectError: regexp.MustCompile(`reached failed state(.|\n)*Requested Reactor Error Code: provisioning_failed`),
+			},
+		},
+	})
+}
+
+func TestResourceReactor_AcceptsOutdatedProvisioning(t *testing.T) {
+	fakeAPIOnly(t).setProvisioningOutcome("Terraform outdated reactor", "outdated")
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "basistheory_reactor" "terraform_test_outdated_reactor" {
+  name          = "Terraform outdated reactor"
+  code          = "module.exports = async function (context) { return context; };"
+  accept_states = ["active", "outdated"]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_outdated_reactor", "state", "outdated"),
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_outdated_reactor", "requested.0.error_code", "provisioning_outdated"),
+				),
+			},
+		},
+	})
+}
+
 func TestResourceReactorWithNode22Runtime(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { preCheck(t) },
@@ -461,20 +654,14 @@ func deleteReactorExternally(resourceName string) resource.TestCheckFunc {
 			return fmt.Errorf("resource not found: %s", resourceName)
 		}
 
-		client := basistheoryClient.NewClient(
-			option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-			option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-		)
+		client := newTestAPIClient()
 
 		return client.Reactors.Delete(context.TODO(), rs.Primary.ID)
 	}
 }
 
 func testAccCheckReactorDestroy(state *terraform.State) error {
-	basisTheoryClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	basisTheoryClient := newTestAPIClient()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "basistheory_reactor" {
@@ -0,0 +1,371 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
+	basistheorycore "github.com/Basis-Theory/go-sdk/v7/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func resourceBasisTheoryToken() *schema.Resource {
+	return &schema.Resource{
+		Description: "Token https://developers.basistheory.com/docs/api/tokens. Meant for long-lived configuration tokens, such as upstream API credentials referenced from Proxy transforms as `{{ token: <id> }}`. The token's `data` is stored in state, so treat the state as sensitive",
+
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		CreateContext: resourceTokenCreate,
+		ReadContext:   resourceTokenRead,
+		UpdateContext: resourceTokenUpdate,
+		DeleteContext: resourceTokenDelete,
+
+		// Updates are merged into the Token, so they cannot clear these
+		CustomizeDiff: customdiff.All(
+			customdiff.ForceNewIfChange("search_indexes", tokenValueRemoved),
+			customdiff.ForceNewIfChange("expires_at", tokenValueRemoved),
+		),
+
+		Schema: map[string]*schema.Schema{
+			"id": {
+				Description: "Unique identifier of the Token",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"token_id": {
+				Description: "Identifier to create the Token with instead of one generated by the API",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+			},
+			"tenant_id": {
+				Description: "Tenant identifier where this Token was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"type": {
+				Description: "Type of the Token, such as `token` or `card`",
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+			},
+			"data": {
+				Description:      "Data to tokenize. Values holding a JSON object or array, such as the output of `jsonencode`, are sent as that object or array; any other value is sent as a string. Changing it replaces the Token. It is never read back from the API, which masks it for keys without `token:reveal`, so it is ignored after import",
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Sensitive:        true,
+				DiffSuppressFunc: suppressTokenDataDiff,
+			},
+			"containers": {
+				Description: "Containers the Token belongs to. Defaults to a container chosen by the API from the Token's type",
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"metadata": {
+				Description: "Non-sensitive key/value pairs stored with the Token",
+				Type:        schema.TypeMap,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"search_indexes": {
+				Description: "Expressions used to index the Token's data for search. Removing every search index replaces the Token",
+				Type:        schema.TypeList,
+				Optional:    true,
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"fingerprint_expression": {
+				Description: "Expression used to fingerprint the Token's data. Defaults to one chosen by the API from the Token's type; removing it keeps the current expression",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"mask": {
+				Description: "Expression used to mask the Token's data. Defaults to one chosen by the API from the Token's type",
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+			},
+			"deduplicate_token": {
+				Description: "Whether to return an existing Token with the same fingerprint instead of creating a new one",
+				Type:        schema.TypeBool,
+				Optional:    true,
+			},
+			"expires_at": {
+				Description:      "RFC 3339 timestamp at which the Token is deleted by the API. Removing it replaces the Token",
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateFunc:     validation.IsRFC3339Time,
+				DiffSuppressFunc: suppressEquivalentTimestamps,
+			},
+			"fingerprint": {
+				Description: "Fingerprint of the Token's data",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_at": {
+				Description: "Timestamp at which the Token was created",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"created_by": {
+				Description: "Identifier for who created the Token",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"modified_at": {
+				Description: "Timestamp at which the Token was last updated",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+			"modified_by": {
+				Description: "Identifier for who last modified the Token",
+				Type:        schema.TypeString,
+				Computed:    true,
+			},
+		},
+	}
+}
+
+func resourceTokenCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	request := &basistheory.CreateTokenRequest{
+		Type:                  getStringPointer(data.Get("type")),
+		Data:                  expandJSONOrString(data.Get("data").(string)),
+		Metadata:              tokenMetadataFromData(data),
+		SearchIndexes:         tokenSearchIndexesFromData(data),
+		FingerprintExpression: getOptionalStringPointer(data, "fingerprint_expression"),
+		ExpiresAt:             getOptionalStringPointer(data, "expires_at"),
+		Containers:            tokenContainersFromData(data),
+	}
+	if tokenID, ok := data.GetOk("token_id"); ok {
+		request.ID = getStringPointer(tokenID)
+	}
+	if mask, ok := data.GetOk("mask"); ok {
+		request.Mask = mask
+	}
+	if deduplicateToken, ok := data.GetOk("deduplicate_token"); ok {
+		value := deduplicateToken.(bool)
+		request.DeduplicateToken = &value
+	}
+
+	token, err := basisTheoryClient.Tokens.Create(ctx, request)
+	if err != nil {
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Token:", err, resourceBasisTheoryToken().Schema)
+	}
+
+	data.SetId(getStringValue(token.ID))
+
+	return resourceTokenRead(ctx, data, meta)
+}
+
+func resourceTokenRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	token, err := basisTheoryClient.Tokens.Get(ctx, data.Id())
+	if err != nil {
+		var notFoundError *basistheory.NotFoundError
+		var apiErr *basistheorycore.APIError
+		if errors.As(err, &notFoundError) || (errors.As(err, &apiErr) && apiErr.StatusCode == 404) {
+			data.SetId("")
+			return diag.Diagnostics{diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Token not found, removing from state",
+				Detail:   "The token resource was not found (it may have been deleted or expired outside of Terraform). It has been removed from state and will be recreated on the next apply.",
+			}}
+		}
+		return apiErrorDiagnostics("Error reading Token:", err)
+	}
+
+	// Tokens are read back masked unless the key can reveal them, so data is
+	// kept as configured and left empty on import.
+	for tokenDatumName, tokenDatum := range flattenToken(token) {
+		if err := data.Set(tokenDatumName, tokenDatum); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceTokenUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	request := &basistheory.UpdateTokenRequest{
+		Metadata:              tokenMetadataFromData(data),
+		SearchIndexes:         tokenSearchIndexesFromData(data),
+		FingerprintExpression: getOptionalStringPointer(data, "fingerprint_expression"),
+		ExpiresAt:             getOptionalStringPointer(data, "expires_at"),
+		Containers:            tokenContainersFromData(data),
+	}
+	if mask, ok := data.GetOk("mask"); ok {
+		request.Mask = mask
+	}
+	if deduplicateToken, ok := data.GetOk("deduplicate_token"); ok {
+		value := deduplicateToken.(bool)
+		request.DeduplicateToken = &value
+	}
+
+	// Updates are merged into the Token, so removed metadata keys are cleared
+	// explicitly.
+	if data.HasChange("metadata") {
+		oldMetadata, _ := data.GetChange("metadata")
+		for key := range oldMetadata.(map[string]interface{}) {
+			if _, ok := request.Metadata[key]; !ok {
+				if request.Metadata == nil {
+					request.Metadata = map[string]*string{}
+				}
+				request.Metadata[key] = nil
+			}
+		}
+	}
+
+	_, err := basisTheoryClient.Tokens.Update(ctx, data.Id(), request)
+	if err != nil {
+		return apiErrorDiagnosticsWithAttributePaths("Error updating Token:", err, resourceBasisTheoryToken().Schema)
+	}
+
+	return resourceTokenRead(ctx, data, meta)
+}
+
+func resourceTokenDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	basisTheoryClient := meta.(map[string]interface{})["client"].(*basistheoryClient.Client)
+
+	err := basisTheoryClient.Tokens.Delete(ctx, data.Id())
+	if err != nil {
+		var notFoundError *basistheory.NotFoundError
+		var apiErr *basistheorycore.APIError
+		if errors.As(err, &notFoundError) || (errors.As(err, &apiErr) && apiErr.StatusCode == 404) {
+			return nil
+		}
+		return apiErrorDiagnostics("Error deleting Token:", err)
+	}
+
+	return nil
+}
+
+// flattenToken returns the Token's non-sensitive attributes.
+func flattenToken(token *basistheory.Token) map[string]interface{} {
+	formatTimestamp := func(timestamp *time.Time) string {
+		if timestamp == nil {
+			return ""
+		}
+		return timestamp.Format(time.RFC3339)
+	}
+
+	flattened := map[string]interface{}{
+		"token_id":               getStringValue(token.ID),
+		"tenant_id":              getStringValue(token.TenantID),
+		"type":                   getStringValue(token.Type),
+		"containers":             token.Containers,
+		"metadata":               flattenTokenMetadata(token.Metadata),
+		"search_indexes":         token.SearchIndexes,
+		"fingerprint_expression": getStringValue(token.FingerprintExpression),
+		"expires_at":             formatTimestamp(token.ExpiresAt),
+		"fingerprint":            getStringValue(token.Fingerprint),
+		"created_at":             formatTimestamp(token.CreatedAt),
+		"created_by":             getStringValue(token.CreatedBy),
+		"modified_at":            formatTimestamp(token.ModifiedAt),
+		"modified_by":            getStringValue(token.ModifiedBy),
+	}
+	if mask, ok := token.Mask.(string); ok {
+		flattened["mask"] = mask
+	}
+
+	return flattened
+}
+
+func flattenTokenMetadata(metadata map[string]*string) map[string]interface{} {
+	flattened := map[string]interface{}{}
+	for key, value := range metadata {
+		flattened[key] = getStringValue(value)
+	}
+
+	return flattened
+}
+
+func tokenMetadataFromData(data *schema.ResourceData) map[string]*string {
+	metadata := map[string]*string{}
+	for key, value := range data.Get("metadata").(map[string]interface{}) {
+		metadata[key] = getStringPointer(value)
+	}
+
+	if len(metadata) == 0 {
+		return nil
+	}
+
+	return metadata
+}
+
+func tokenSearchIndexesFromData(data *schema.ResourceData) []string {
+	var searchIndexes []string
+	for _, searchIndex := range data.Get("search_indexes").([]interface{}) {
+		searchIndexes = append(searchIndexes, searchIndex.(string))
+	}
+
+	return searchIndexes
+}
+
+func tokenContainersFromData(data *schema.ResourceData) []string {
+	var containers []string
+	if dataContainers, ok := data.Get("containers").(*schema.Set); ok {
+		for _, container := range dataContainers.List() {
+			containers = append(containers, container.(string))
+		}
+	}
+
+	return containers
+}
+
+func getOptionalStringPointer(data *schema.ResourceData, key string) *string {
+	if value, ok := data.GetOk(key); ok {
+		return getStringPointer(value)
+	}
+
+	return nil
+}
+
+// tokenValueRemoved reports whether a value was removed from the
+// configuration, which an update cannot send.
+func tokenValueRemoved(_ context.Context, old, new, _ interface{}) bool {
+	switch old := old.(type) {
+	case string:
+		return old != "" && new.(string) == ""
+	case []interface{}:
+		return len(old) > 0 && len(new.([]interface{})) == 0
+	}
+
+	return false
+}
+
+// suppressTokenDataDiff ignores data that is only written differently, and
+// data of an imported Token, which is never read back from the API.
+func suppressTokenDataDiff(key, old, new string, data *schema.ResourceData) bool {
+	return suppressWriteOnlyDiffAfterImport(key, old, new, data) || suppressEquivalentJSONDiffs(key, old, new, data)
+}
+
+// suppressEquivalentTimestamps ignores differences in how the same RFC 3339
+// instant is written, such as its time zone offset.
+func suppressEquivalentTimestamps(_, old, new string, _ *schema.ResourceData) bool {
+	oldTime, oldErr := time.Parse(time.RFC3339, old)
+	newTime, newErr := time.Parse(time.RFC3339, new)
+
+	return oldErr == nil && newErr == nil && oldTime.Equal(newTime)
+}
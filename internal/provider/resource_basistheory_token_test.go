@@ -0,0 +1,296 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	basistheory "github.com/Basis-Theory/go-sdk/v7"
+	basistheorycore "github.com/Basis-Theory/go-sdk/v7/core"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestResourceToken(t *testing.T) {
+	var createdID string
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testTokenConfig, "sk_test_1234", "prod", `"/general/high/"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "type", "token"),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "data", "sk_test_1234"),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "metadata.env", "prod"),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "containers.#", "1"),
+					resource.TestCheckResourceAttrSet(
+						"basistheory_token.terraform_test_token", "fingerprint"),
+					testAccCaptureResourceID("basistheory_token.terraform_test_token", &createdID),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testTokenConfig, "sk_test_1234", "staging", `"/general/high/", "/upstream/"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "metadata.env", "staging"),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "containers.#", "2"),
+					testAccCheckResourceID("basistheory_token.terraform_test_token", &createdID, true),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testTokenConfig, "sk_test_5678", "staging", `"/general/high/", "/upstream/"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token", "data", "sk_test_5678"),
+					testAccCheckResourceID("basistheory_token.terraform_test_token", &createdID, false),
+				),
+			},
+			{
+				ResourceName:            "basistheory_token.terraform_test_token",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"data", "deduplicate_token"},
+			},
+		},
+	})
+}
+
+func TestResourceTokenWithTokenIdAndObjectData(t *testing.T) {
+	tokenID := "terraform-test-" + newFakeUUID()
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckTokenDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+resource "basistheory_token" "terraform_test_token_object" {
+  token_id = "%s"
+  type     = "token"
+  data = jsonencode({
+    client_id     = "abc"
+    client_secret = "xyz"
+  })
+  search_indexes = ["{{ data.client_id }}"]
+  expires_at     = "2099-01-01T00:00:00Z"
+}
+`, tokenID),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token_object", "id", tokenID),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token_object", "token_id", tokenID),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token_object", "search_indexes.0", "{{ data.client_id }}"),
+					resource.TestCheckResourceAttr(
+						"basistheory_token.terraform_test_token_object", "expires_at", "2099-01-01T00:00:00Z"),
+				),
+			},
+		},
+	})
+}
+
+func TestExpandJSONOrString_tokenData(t *testing.T) {
+	for value, expected := range map[string]interface{}{
+		"sk_test_1234":       "sk_test_1234",
+		`"quoted"`:           `"quoted"`,
+		"42":                 "42",
+		`{"number":"4242"}`:  map[string]interface{}{"number": "4242"},
+		`["first","second"]`: []interface{}{"first", "second"},
+		`{"number": "4242"`:  `{"number": "4242"`,
+	} {
+		if actual := expandJSONOrString(value); !reflect.DeepEqual(actual, expected) {
+			t.Fatalf("expected %q to be sent as %#v, got %#v", value, expected, actual)
+		}
+	}
+
+	if actual := flattenJSONOrString(map[string]interface{}{"number": "4242"}); actual != `{"number":"4242"}` {
+		t.Fatalf("expected object data to be JSON encoded, got %s", actual)
+	}
+}
+
+func TestResourceTokenCustomizeDiff_replacesWhenUnclearableValuesAreRemoved(t *testing.T) {
+	state := &terraform.InstanceState{
+		ID: "token-id",
+		Attributes: map[string]string{
+			"id":                     "token-id",
+			"token_id":               "token-id",
+			"type":                   "token",
+			"data":                   "sk_test_1234",
+			"search_indexes.#":       "1",
+			"search_indexes.0":       "{{ data }}",
+			"fingerprint_expression": "{{ data }}",
+			"expires_at":             "2099-01-01T00:00:00Z",
+		},
+	}
+
+	for name, testCase := range map[string]struct {
+		config              map[string]interface{}
+		expectedRequiresNew []string
+		expectedInPlace     []string
+	}{
+		"removed": {
+			config:              map[string]interface{}{"type": "token", "data": "sk_test_1234"},
+			expectedRequiresNew: []string{"search_indexes.#", "expires_at"},
+		},
+		"changed": {
+			config: map[string]interface{}{
+				"type":           "token",
+				"data":           "sk_test_1234",
+				"search_indexes": []interface{}{"{{ data | slice: 0, 4 }}"},
+				"expires_at":     "2100-01-01T00:00:00Z",
+			},
+			expectedInPlace: []string{"search_indexes.0", "expires_at"},
+		},
+	} {
+		diff, err := resourceBasisTheoryToken().Diff(context.Background(), state, terraform.NewResourceConfigRaw(testCase.config), nil)
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %s", name, err)
+		}
+		if diff == nil {
+			t.Fatalf("%s: expected a diff", name)
+		}
+
+		for _, key := range testCase.expectedRequiresNew {
+			if attribute, ok := diff.Attributes[key]; !ok || !attribute.RequiresNew {
+				t.Fatalf("%s: expected %s to replace the Token, got %#v", name, key, diff.Attributes[key])
+			}
+		}
+		for _, key := range testCase.expectedInPlace {
+			if attribute, ok := diff.Attributes[key]; !ok || attribute.RequiresNew {
+				t.Fatalf("%s: expected %s to be updated in place, got %#v", name, key, diff.Attributes[key])
+			}
+		}
+	}
+
+	diff, err := resourceBasisTheoryToken().Diff(context.Background(), state, terraform.NewResourceConfigRaw(map[string]interface{}{
+		"type":           "token",
+		"data":           "sk_test_1234",
+		"search_indexes": []interface{}{"{{ data }}"},
+		"expires_at":     "2099-01-01T00:00:00Z",
+	}), nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && (diff.RequiresNew() || diff.Attributes["fingerprint_expression"] != nil) {
+		t.Fatalf("expected removing fingerprint_expression to keep its current value, got %#v", diff.Attributes)
+	}
+}
+
+func TestResourceTokenDiff_keepsImportedTokenWithMaskedData(t *testing.T) {
+	// Import leaves data empty rather than storing the masked value the API
+	// returns to keys without token:reveal.
+	imported := &terraform.InstanceState{
+		ID: "token-id",
+		Attributes: map[string]string{
+			"id":       "token-id",
+			"token_id": "token-id",
+			"type":     "token",
+		},
+	}
+	config := terraform.NewResourceConfigRaw(map[string]interface{}{"type": "token", "data": "sk_test_1234"})
+
+	diff, err := resourceBasisTheoryToken().Diff(context.Background(), imported, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff != nil && (diff.RequiresNew() || diff.Attributes["data"] != nil) {
+		t.Fatalf("expected the imported Token to be kept, got %#v", diff.Attributes)
+	}
+
+	diff, err = resourceBasisTheoryToken().Diff(context.Background(), nil, config, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if diff == nil || diff.Attributes["data"] == nil || diff.Attributes["data"].New != "sk_test_1234" {
+		t.Fatalf("expected data to be set on a new Token, got %#v", diff)
+	}
+}
+
+func TestSuppressEquivalentTimestamps(t *testing.T) {
+	if !suppressEquivalentTimestamps("", "2099-01-01T00:00:00Z", "2099-01-01T01:00:00+01:00", nil) {
+		t.Fatalf("expected the same instant to be suppressed")
+	}
+	if suppressEquivalentTimestamps("", "2099-01-01T00:00:00Z", "2099-01-02T00:00:00Z", nil) {
+		t.Fatalf("expected different instants not to be suppressed")
+	}
+	if suppressEquivalentTimestamps("", "", "2099-01-01T00:00:00Z", nil) {
+		t.Fatalf("expected a new timestamp not to be suppressed")
+	}
+}
+
+const testTokenConfig = `
+resource "basistheory_token" "terraform_test_token" {
+  type = "token"
+  data = "%s"
+  metadata = {
+    env     = "%s"
+    purpose = "upstream-api-key"
+  }
+  containers = [%s]
+}
+`
+
+func testAccCaptureResourceID(resourceName string, id *string) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckResourceID(resourceName string, id *string, same bool) resource.TestCheckFunc {
+	return func(state *terraform.State) error {
+		rs, ok := state.RootModule().Resources[resourceName]
+		if !ok {
+			return fmt.Errorf("%s not found in state", resourceName)
+		}
+
+		if same && rs.Primary.ID != *id {
+			return fmt.Errorf("expected %s to be updated in place, but it was replaced by %s", resourceName, rs.Primary.ID)
+		}
+		if !same && rs.Primary.ID == *id {
+			return fmt.Errorf("expected %s to be replaced, but it kept id %s", resourceName, rs.Primary.ID)
+		}
+
+		*id = rs.Primary.ID
+		return nil
+	}
+}
+
+func testAccCheckTokenDestroy(state *terraform.State) error {
+	basisTheoryClient := newTestAPIClient()
+
+	for _, rs := range state.RootModule().Resources {
+		if rs.Type != "basistheory_token" {
+			continue
+		}
+
+		_, err := basisTheoryClient.Tokens.Get(context.TODO(), rs.Primary.ID)
+		if err == nil {
+			return fmt.Errorf("token %s still exists", rs.Primary.ID)
+		}
+		var notFoundError *basistheory.NotFoundError
+		var apiErr *basistheorycore.APIError
+		if !errors.As(err, &notFoundError) && !(errors.As(err, &apiErr) && apiErr.StatusCode == 404) {
+			return err
+		}
+	}
+
+	return nil
+}
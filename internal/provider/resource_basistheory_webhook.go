@@ -9,11 +9,15 @@ import (
 	basistheorycore "github.com/Basis-Theory/go-sdk/v7/core"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceBasisTheoryWebhook() *schema.Resource {
 
 	return &schema.Resource{
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
 		CreateContext: resourceWebhookCreate,
 		ReadContext:   resourceWebhookRead,
 		UpdateContext: resourceWebhookUpdate,
@@ -52,6 +56,19 @@ func resourceBasisTheoryWebhook() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"status": {
+				Description:  "Whether the Webhook is `enabled` or `disabled`. Disabled Webhooks are kept but not notified of events. Defaults to `enabled`",
+				Type:         schema.TypeString,
+				Optional:     true,
+				Default:      string(basistheory.WebhookStatusEnabled),
+				ValidateFunc: validation.StringInSlice([]string{string(basistheory.WebhookStatusEnabled), string(basistheory.WebhookStatusDisabled)}, false),
+			},
+			"signing_key": {
+				Description: "Key used to sign the payloads sent to the Tenant's Webhooks, which receivers use to verify them. It is shared by every Webhook in the Tenant",
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+			},
 			"created_at": {
 				Description: "Timestamp at which the Webhook was created",
 				Type:        schema.TypeString,
@@ -90,11 +107,28 @@ func resourceWebhookCreate(ctx context.Context, data *schema.ResourceData, meta
 
 	response, err := basisTheoryClient.Webhooks.Create(ctx, request)
 	if err != nil {
-		return apiErrorDiagnostics("Error creating Webhook:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error creating Webhook:", err, resourceBasisTheoryWebhook().Schema)
 	}
 
 	data.SetId(response.ID)
-	return nil
+
+	// Webhooks are always created enabled, so other statuses are set with an
+	// update.
+	if status := data.Get("status").(string); basistheory.WebhookStatus(status) != response.Status {
+		webhookStatus := basistheory.WebhookStatus(status)
+		_, err := basisTheoryClient.Webhooks.Update(ctx, response.ID, &basistheory.UpdateWebhookRequest{
+			Name:        webhook.Name,
+			URL:         webhook.URL,
+			NotifyEmail: webhook.NotifyEmail,
+			Events:      webhook.Events,
+			Status:      &webhookStatus,
+		})
+		if err != nil {
+			return apiErrorDiagnosticsWithAttributePaths("Error updating Webhook status:", err, resourceBasisTheoryWebhook().Schema)
+		}
+	}
+
+	return setWebhookSigningKey(ctx, basisTheoryClient, data)
 }
 
 func resourceWebhookRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -129,6 +163,7 @@ func resourceWebhookRead(ctx context.Context, data *schema.ResourceData, meta in
 		"url":          webhook.URL,
 		"notify_email": webhook.NotifyEmail,
 		"events":       webhook.Events,
+		"status":       string(webhook.Status),
 		"created_at":   webhook.CreatedAt.String(),
 		"created_by":   webhook.CreatedBy,
 		"modified_at":  modifiedAt,
@@ -141,7 +176,7 @@ func resourceWebhookRead(ctx context.Context, data *schema.ResourceData, meta in
 		}
 	}
 
-	return nil
+	return setWebhookSigningKey(ctx, basisTheoryClient, data)
 }
 
 func resourceWebhookUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -149,19 +184,21 @@ func resourceWebhookUpdate(ctx context.Context, data *schema.ResourceData, meta
 
 	webhook := getWebhookFromData(data)
 
+	status := basistheory.WebhookStatus(data.Get("status").(string))
 	request := &basistheory.UpdateWebhookRequest{
 		Name:        webhook.Name,
 		URL:         webhook.URL,
 		NotifyEmail: webhook.NotifyEmail,
 		Events:      webhook.Events,
+		Status:      &status,
 	}
 
 	_, err := basisTheoryClient.Webhooks.Update(ctx, data.Id(), request)
 	if err != nil {
-		return apiErrorDiagnostics("Error updating Webhook:", err)
+		return apiErrorDiagnosticsWithAttributePaths("Error updating Webhook:", err, resourceBasisTheoryWebhook().Schema)
 	}
 
-	return nil
+	return setWebhookSigningKey(ctx, basisTheoryClient, data)
 }
 
 func resourceWebhookDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
@@ -180,6 +217,19 @@ func resourceWebhookDelete(ctx context.Context, data *schema.ResourceData, meta
 	return nil
 }
 
+func setWebhookSigningKey(ctx context.Context, client *basistheoryClient.Client, data *schema.ResourceData) diag.Diagnostics {
+	response, err := client.Webhooks.SigningKey.Get(ctx)
+	if err != nil {
+		return apiErrorDiagnostics("Error reading Webhook signing key:", err)
+	}
+
+	if err := data.Set("signing_key", getStringValue(response.GetSigningKey())); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
 func getWebhookFromData(data *schema.ResourceData) *basistheory.Webhook {
 	var events []string
 	if dataEvents, ok := data.Get("events").(*schema.Set); ok {
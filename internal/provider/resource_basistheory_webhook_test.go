@@ -5,12 +5,9 @@ import (
 	"errors"
 	"fmt"
 	basistheory "github.com/Basis-Theory/go-sdk/v7"
-	basistheoryClient "github.com/Basis-Theory/go-sdk/v7/client"
 	basistheorycore "github.com/Basis-Theory/go-sdk/v7/core"
-	"github.com/Basis-Theory/go-sdk/v7/option"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
-	"os"
 	"testing"
 	"time"
 )
@@ -51,6 +48,11 @@ func TestResourceWebhook(t *testing.T) {
 					pauseForSeconds(2), // Required to avoid error `The webhook subscription is undergoing another concurrent operation. Please wait a few seconds, then try again.
 				),
 			},
+			{
+				ResourceName:      "basistheory_webhook.terraform_test_webhook",
+				ImportState:       true,
+				ImportStateVerify: true,
+			},
 		},
 	})
 }
@@ -107,6 +109,36 @@ func TestResourceWebhook_UpdateOptionalAttributesFromSomethingToNil(t *testing.T
 	})
 }
 
+func TestResourceWebhookWithStatusAndSigningKey(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckWebhookDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: buildWebhookWithOptionalParameters("terraform_test_webhook_status", `status = "disabled"`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_webhook.terraform_test_webhook_status", "status", "disabled"),
+					resource.TestCheckResourceAttrSet(
+						"basistheory_webhook.terraform_test_webhook_status", "signing_key"),
+					pauseForSeconds(2), // Required to avoid error `The webhook subscription is undergoing another concurrent operation. Please wait a few seconds, then try again.
+				),
+			},
+			{
+				Config: buildWebhookWithOptionalParameters("terraform_test_webhook_status", ""),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_webhook.terraform_test_webhook_status", "status", "enabled"),
+					resource.TestCheckResourceAttrSet(
+						"basistheory_webhook.terraform_test_webhook_status", "signing_key"),
+					pauseForSeconds(2), // Required to avoid error `The webhook subscription is undergoing another concurrent operation. Please wait a few seconds, then try again.
+				),
+			},
+		},
+	})
+}
+
 func TestResourceWebhook_HandlesGraceful404(t *testing.T) {
 	resource.UnitTest(t, resource.TestCase{
 		PreCheck:          func() { preCheck(t) },
@@ -131,20 +163,14 @@ func deleteWebhookExternally(resourceName string) resource.TestCheckFunc {
 			return fmt.Errorf("resource not found: %s", resourceName)
 		}
 
-		client := basistheoryClient.NewClient(
-			option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-			option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-		)
+		client := newTestAPIClient()
 
 		return client.Webhooks.Delete(context.TODO(), rs.Primary.ID)
 	}
 }
 
 func testAccCheckWebhookDestroy(state *terraform.State) error {
-	basisTheoryClient := basistheoryClient.NewClient(
-		option.WithAPIKey(os.Getenv("BASISTHEORY_API_KEY")),
-		option.WithBaseURL(os.Getenv("BASISTHEORY_API_URL")),
-	)
+	basisTheoryClient := newTestAPIClient()
 
 	for _, rs := range state.RootModule().Resources {
 		if rs.Type != "basistheory_webhook" {
@@ -0,0 +1,156 @@
+package provider
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// retryHTTPClient wraps an http.Client and retries requests that fail with a
+// transient status code. Each attempt gets the full timeout of the wrapped
+// client, so waiting between attempts never eats into a single request's
+// budget.
+type retryHTTPClient struct {
+	client     *http.Client
+	maxRetries int
+	minWait    time.Duration
+	maxWait    time.Duration
+}
+
+func newRetryHTTPClient(client *http.Client, maxRetries int, minWait time.Duration, maxWait time.Duration) *retryHTTPClient {
+	return &retryHTTPClient{
+		client:     client,
+		maxRetries: maxRetries,
+		minWait:    minWait,
+		maxWait:    maxWait,
+	}
+}
+
+func (c *retryHTTPClient) Do(req *http.Request) (*http.Response, error) {
+	if err := ensureRequestBodyIsReplayable(req); err != nil {
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		resp, err := c.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		if attempt >= c.maxRetries || !isRetryableRequest(req, resp.StatusCode) {
+			return resp, nil
+		}
+
+		wait := c.waitDuration(attempt, resp)
+
+		// Release the connection before sleeping so it can be reused by the retry.
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// waitDuration honors a Retry-After header when present and otherwise backs off
+// exponentially from minWait. The result is always capped at maxWait.
+func (c *retryHTTPClient) waitDuration(attempt int, resp *http.Response) time.Duration {
+	if retryAfter, ok := parseRetryAfter(resp.Header.Get("Retry-After"), time.Now()); ok {
+		if retryAfter > c.maxWait {
+			return c.maxWait
+		}
+		return retryAfter
+	}
+
+	backoff := float64(c.minWait) * math.Pow(2, float64(attempt))
+	if backoff > float64(c.maxWait) {
+		return c.maxWait
+	}
+
+	return time.Duration(backoff)
+}
+
+// isRetryableRequest only retries calls that are safe to repeat. A 429 means the
+// API throttled the request before processing it, so any method may be retried.
+// Gateway errors may have been raised after the API acted on the request, so
+// those are only retried for idempotent methods or requests carrying an
+// idempotency key.
+func isRetryableRequest(req *http.Request, statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return isIdempotentRequest(req)
+	}
+
+	return false
+}
+
+func isIdempotentRequest(req *http.Request) bool {
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+
+	return req.Header.Get("BT-IDEMPOTENCY-KEY") != ""
+}
+
+// parseRetryAfter supports both forms of the Retry-After header: a number of
+// seconds or an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		wait := date.Sub(now)
+		if wait < 0 {
+			wait = 0
+		}
+		return wait, true
+	}
+
+	return 0, false
+}
+
+func ensureRequestBodyIsReplayable(req *http.Request) error {
+	if req.Body == nil || req.Body == http.NoBody || req.GetBody != nil {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	_ = req.Body.Close()
+	if err != nil {
+		return err
+	}
+
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	req.GetBody = func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(body)), nil
+	}
+
+	return nil
+}
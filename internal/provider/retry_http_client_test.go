@@ -0,0 +1,181 @@
+package provider
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newTestRetryServer(t *testing.T, statuses []int, headers map[string]string) (*httptest.Server, *int32) {
+	var calls int32
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := int(atomic.AddInt32(&calls, 1)) - 1
+
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Request-Body", string(body))
+
+		status := http.StatusOK
+		if call < len(statuses) {
+			status = statuses[call]
+		}
+		if status != http.StatusOK {
+			for key, value := range headers {
+				w.Header().Set(key, value)
+			}
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &calls
+}
+
+func TestRetryHTTPClient_retriesThrottledAndGatewayErrorsForGet(t *testing.T) {
+	server, calls := newTestRetryServer(t, []int{429, 502, 503, 504}, nil)
+	client := newRetryHTTPClient(server.Client(), 5, time.Millisecond, 5*time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if actual := atomic.LoadInt32(calls); actual != 5 {
+		t.Fatalf("expected 5 calls, got %d", actual)
+	}
+}
+
+func TestRetryHTTPClient_stopsAfterMaxRetries(t *testing.T) {
+	server, calls := newTestRetryServer(t, []int{503, 503, 503, 503}, nil)
+	client := newRetryHTTPClient(server.Client(), 2, time.Millisecond, time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodDelete, server.URL, nil)
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusServiceUnavailable {
+		t.Fatalf("expected 503, got %d", resp.StatusCode)
+	}
+	if actual := atomic.LoadInt32(calls); actual != 3 {
+		t.Fatalf("expected 3 calls, got %d", actual)
+	}
+}
+
+func TestRetryHTTPClient_doesNotRetryGatewayErrorsForPost(t *testing.T) {
+	server, calls := newTestRetryServer(t, []int{502}, nil)
+	client := newRetryHTTPClient(server.Client(), 3, time.Millisecond, time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{"name":"proxy"}`))
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected 502, got %d", resp.StatusCode)
+	}
+	if actual := atomic.LoadInt32(calls); actual != 1 {
+		t.Fatalf("expected 1 call, got %d", actual)
+	}
+}
+
+func TestRetryHTTPClient_retriesThrottledPostAndReplaysBody(t *testing.T) {
+	server, calls := newTestRetryServer(t, []int{429}, nil)
+	client := newRetryHTTPClient(server.Client(), 3, time.Millisecond, time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, io.NopCloser(strings.NewReader(`{"name":"proxy"}`)))
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if actual := resp.Header.Get("X-Request-Body"); actual != `{"name":"proxy"}` {
+		t.Fatalf("expected request body to be replayed, got %q", actual)
+	}
+	if actual := atomic.LoadInt32(calls); actual != 2 {
+		t.Fatalf("expected 2 calls, got %d", actual)
+	}
+}
+
+func TestRetryHTTPClient_retriesGatewayErrorsForPostWithIdempotencyKey(t *testing.T) {
+	server, calls := newTestRetryServer(t, []int{504}, nil)
+	client := newRetryHTTPClient(server.Client(), 3, time.Millisecond, time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader(`{}`))
+	req.Header.Set("BT-IDEMPOTENCY-KEY", "key_123")
+	resp, err := client.Do(req)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if actual := atomic.LoadInt32(calls); actual != 2 {
+		t.Fatalf("expected 2 calls, got %d", actual)
+	}
+}
+
+func TestRetryHTTPClient_doesNotRetryClientErrors(t *testing.T) {
+	server, calls := newTestRetryServer(t, []int{400, 500}, nil)
+	client := newRetryHTTPClient(server.Client(), 3, time.Millisecond, time.Millisecond)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	resp, _ := client.Do(req)
+
+	if resp.StatusCode != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", resp.StatusCode)
+	}
+	if actual := atomic.LoadInt32(calls); actual != 1 {
+		t.Fatalf("expected 1 call, got %d", actual)
+	}
+}
+
+func TestRetryHTTPClient_waitDurationHonorsRetryAfter(t *testing.T) {
+	client := newRetryHTTPClient(http.DefaultClient, 3, time.Second, 10*time.Second)
+
+	resp := &http.Response{Header: http.Header{"Retry-After": {"4"}}}
+	if actual := client.waitDuration(0, resp); actual != 4*time.Second {
+		t.Fatalf("expected 4s, got %s", actual)
+	}
+
+	resp = &http.Response{Header: http.Header{"Retry-After": {"120"}}}
+	if actual := client.waitDuration(0, resp); actual != 10*time.Second {
+		t.Fatalf("expected Retry-After to be capped at 10s, got %s", actual)
+	}
+}
+
+func TestRetryHTTPClient_waitDurationBacksOffExponentially(t *testing.T) {
+	client := newRetryHTTPClient(http.DefaultClient, 10, time.Second, 10*time.Second)
+	resp := &http.Response{Header: http.Header{}}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+	for attempt, expectedWait := range expected {
+		if actual := client.waitDuration(attempt, resp); actual != expectedWait {
+			t.Fatalf("attempt %d: expected %s, got %s", attempt, expectedWait, actual)
+		}
+	}
+}
+
+func TestParseRetryAfter_supportsHttpDate(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	actual, ok := parseRetryAfter(now.Add(7*time.Second).Format(http.TimeFormat), now)
+	if !ok || actual != 7*time.Second {
+		t.Fatalf("expected 7s, got %s (ok=%t)", actual, ok)
+	}
+
+	if _, ok := parseRetryAfter("not-a-date", now); ok {
+		t.Fatalf("expected invalid Retry-After to be ignored")
+	}
+}
@@ -0,0 +1,119 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const sensitiveConfigurationKey = "sensitive_configuration"
+
+func sensitiveConfigurationSchema(resourceName string) *schema.Schema {
+	return &schema.Schema{
+		Description: fmt.Sprintf("Configuration for the %s that is hidden from plan output, such as upstream API secrets. It is merged with `configuration`, which must not set the same keys. On import, every configuration key is placed here, since the API does not record which keys are sensitive", resourceName),
+		Type:        schema.TypeMap,
+		Optional:    true,
+		Sensitive:   true,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// withSensitiveDataSourceConfiguration marks a data source's configuration
+// as sensitive: the API returns sensitive_configuration merged into it, and a
+// data source cannot tell which keys were meant to be hidden.
+func withSensitiveDataSourceConfiguration(dataSourceSchema map[string]*schema.Schema) map[string]*schema.Schema {
+	configuration := dataSourceSchema["configuration"]
+	configuration.Sensitive = true
+	configuration.Description += ", including any `sensitive_configuration`"
+
+	return dataSourceSchema
+}
+
+// configurationFromData merges configuration and sensitive_configuration into
+// the single map the API expects.
+func configurationFromData(data *schema.ResourceData) map[string]*string {
+	configuration := map[string]*string{}
+
+	for _, key := range []string{"configuration", sensitiveConfigurationKey} {
+		for name, value := range data.Get(key).(map[string]interface{}) {
+			configuration[name] = getStringPointer(value)
+		}
+	}
+
+	return configuration
+}
+
+// splitConfiguration splits the API's configuration back into configuration
+// and sensitive_configuration by key ownership: keys held in
+// sensitive_configuration stay there and every other key, including keys
+// added outside of Terraform, goes to configuration. When neither map is in
+// state yet, as on import, every key goes to sensitive_configuration so that
+// secrets are not shown in plan output.
+func splitConfiguration(data *schema.ResourceData, configuration map[string]*string) (map[string]interface{}, map[string]interface{}) {
+	publicKeys := data.Get("configuration").(map[string]interface{})
+	sensitiveKeys := data.Get(sensitiveConfigurationKey).(map[string]interface{})
+	imported := len(publicKeys) == 0 && len(sensitiveKeys) == 0
+
+	public := map[string]interface{}{}
+	sensitive := map[string]interface{}{}
+	for name, value := range configuration {
+		if _, ok := sensitiveKeys[name]; ok || imported {
+			sensitive[name] = getStringValue(value)
+		} else {
+			public[name] = getStringValue(value)
+		}
+	}
+
+	return public, sensitive
+}
+
+// sensitiveConfigurationAttributePath points API errors on configuration keys
+// at sensitive_configuration when that is where data sets them, since the API
+// only knows the merged configuration.
+func sensitiveConfigurationAttributePath(data *schema.ResourceData) func(cty.Path) cty.Path {
+	return func(path cty.Path) cty.Path {
+		if len(path) != 2 || attributePathKey(path[:1]) != "configuration" {
+			return path
+		}
+
+		key, ok := path[1].(cty.IndexStep)
+		if !ok || key.Key.Type() != cty.String {
+			return path
+		}
+
+		if _, ok := data.Get(sensitiveConfigurationKey).(map[string]interface{})[key.Key.AsString()]; !ok {
+			return path
+		}
+
+		return cty.GetAttrPath(sensitiveConfigurationKey).Index(key.Key)
+	}
+}
+
+// sensitiveConfigurationCustomizeDiff fails the plan when a key is set in both
+// configuration and sensitive_configuration, since only one value can be sent.
+func sensitiveConfigurationCustomizeDiff(_ context.Context, diff *schema.ResourceDiff, _ interface{}) error {
+	if !diff.NewValueKnown("configuration") || !diff.NewValueKnown(sensitiveConfigurationKey) {
+		return nil
+	}
+
+	configuration := diff.Get("configuration").(map[string]interface{})
+
+	var overlapping []string
+	for name := range diff.Get(sensitiveConfigurationKey).(map[string]interface{}) {
+		if _, ok := configuration[name]; ok {
+			overlapping = append(overlapping, name)
+		}
+	}
+
+	if len(overlapping) == 0 {
+		return nil
+	}
+
+	sort.Strings(overlapping)
+	return cty.GetAttrPath(sensitiveConfigurationKey).IndexString(overlapping[0]).NewError(fmt.Errorf("configuration keys %v are also set in sensitive_configuration; set each key in only one of them", overlapping))
+}
@@ -0,0 +1,141 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestConfigurationFromData_mergesSensitiveConfiguration(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryReactor().Schema, map[string]interface{}{
+		"configuration":           map[string]interface{}{"BASE_URL": "https://api.example.com"},
+		sensitiveConfigurationKey: map[string]interface{}{"API_KEY": "secret"},
+	})
+
+	actual := configurationFromData(data)
+
+	if len(actual) != 2 || getStringValue(actual["BASE_URL"]) != "https://api.example.com" || getStringValue(actual["API_KEY"]) != "secret" {
+		t.Fatalf("expected both maps to be merged, got %v", actual)
+	}
+}
+
+func TestSplitConfiguration_byKeyOwnership(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryReactor().Schema, map[string]interface{}{
+		"configuration":           map[string]interface{}{"BASE_URL": "https://api.example.com"},
+		sensitiveConfigurationKey: map[string]interface{}{"API_KEY": "secret"},
+	})
+	baseURL, apiKey, addedOutside := "https://api.example.com", "rotated", "added"
+
+	public, sensitive := splitConfiguration(data, map[string]*string{
+		"BASE_URL":      &baseURL,
+		"API_KEY":       &apiKey,
+		"ADDED_OUTSIDE": &addedOutside,
+	})
+
+	if expected := map[string]interface{}{"BASE_URL": baseURL, "ADDED_OUTSIDE": addedOutside}; !reflect.DeepEqual(public, expected) {
+		t.Fatalf("expected configuration %v, got %v", expected, public)
+	}
+	if expected := map[string]interface{}{"API_KEY": apiKey}; !reflect.DeepEqual(sensitive, expected) {
+		t.Fatalf("expected sensitive_configuration %v, got %v", expected, sensitive)
+	}
+}
+
+func TestSplitConfiguration_keepsEveryKeySensitiveOnImport(t *testing.T) {
+	data := schema.TestResourceDataRaw(t, resourceBasisTheoryReactor().Schema, map[string]interface{}{})
+	data.SetId("reactor-id")
+	baseURL, apiKey := "https://api.example.com", "secret"
+
+	public, sensitive := splitConfiguration(data, map[string]*string{
+		"BASE_URL": &baseURL,
+		"API_KEY":  &apiKey,
+	})
+
+	if len(public) != 0 {
+		t.Fatalf("expected no configuration on import, got %v", public)
+	}
+	if expected := map[string]interface{}{"BASE_URL": baseURL, "API_KEY": apiKey}; !reflect.DeepEqual(sensitive, expected) {
+		t.Fatalf("expected sensitive_configuration %v, got %v", expected, sensitive)
+	}
+}
+
+func TestSensitiveConfigurationCustomizeDiff_rejectsOverlappingKeys(t *testing.T) {
+	for name, resource := range map[string]*schema.Resource{
+		"Proxy":   resourceBasisTheoryProxy(),
+		"Reactor": resourceBasisTheoryReactor(),
+	} {
+		_, err := resource.Diff(context.Background(), nil, terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":                    "Terraform " + name,
+			"destination_url":         "https://httpbin.org/post",
+			"code":                    "module.exports = async function (context) { return context; };",
+			"configuration":           map[string]interface{}{"API_KEY": "public", "BASE_URL": "https://api.example.com"},
+			sensitiveConfigurationKey: map[string]interface{}{"API_KEY": "secret"},
+		}), nil)
+
+		var pathError cty.PathError
+		if !errors.As(err, &pathError) {
+			t.Fatalf("%s: expected a path error, got %v", name, err)
+		}
+		if expected := cty.GetAttrPath(sensitiveConfigurationKey).IndexString("API_KEY"); !pathError.Path.Equals(expected) {
+			t.Fatalf("%s: expected path %#v, got %#v", name, expected, pathError.Path)
+		}
+		if strings.Contains(err.Error(), "secret") || !strings.Contains(err.Error(), "[API_KEY]") {
+			t.Fatalf("%s: expected the key and not the value to be reported, got %s", name, err)
+		}
+	}
+}
+
+func TestWithSensitiveDataSourceConfiguration(t *testing.T) {
+	for name, configuration := range map[string]*schema.Schema{
+		"basistheory_proxy":    dataSourceBasisTheoryProxy().Schema["configuration"],
+		"basistheory_proxies":  dataSourceBasisTheoryProxies().Schema["proxies"].Elem.(*schema.Resource).Schema["configuration"],
+		"basistheory_reactor":  dataSourceBasisTheoryReactor().Schema["configuration"],
+		"basistheory_reactors": dataSourceBasisTheoryReactors().Schema["reactors"].Elem.(*schema.Resource).Schema["configuration"],
+	} {
+		if !configuration.Sensitive {
+			t.Fatalf("%s: expected configuration to be sensitive", name)
+		}
+	}
+
+	if resourceBasisTheoryReactor().Schema["configuration"].Sensitive {
+		t.Fatalf("expected the resource's configuration to stay visible")
+	}
+}
+
+func TestResourceReactorWithSensitiveConfiguration(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:          func() { preCheck(t) },
+		ProviderFactories: getProviderFactories(),
+		CheckDestroy:      testAccCheckReactorDestroy,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+resource "basistheory_reactor" "terraform_test_reactor_sensitive_configuration" {
+  name = "Terraform reactor with sensitive configuration"
+  code = "module.exports = async function (context) { return context; };"
+  configuration = {
+    BASE_URL = "https://api.example.com"
+  }
+  sensitive_configuration = {
+    API_KEY = "key_abcd1234"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_reactor_sensitive_configuration", "configuration.%", "1"),
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_reactor_sensitive_configuration", "configuration.BASE_URL", "https://api.example.com"),
+					resource.TestCheckResourceAttr(
+						"basistheory_reactor.terraform_test_reactor_sensitive_configuration", "sensitive_configuration.API_KEY", "key_abcd1234"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,15 @@
+#!/bin/bash
+
+current_directory="$PWD"
+
+cd $(dirname $0)
+cd ../
+
+go clean -testcache
+BASISTHEORY_FAKE_API=1 TF_ACC=1 go test ./... -timeout 30m
+
+result=$?
+
+cd "$current_directory"
+
+exit $result